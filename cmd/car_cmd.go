@@ -0,0 +1,435 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+// generatedCar is a CAR written by car generate
+type generatedCar struct {
+	Path         string `json:"path"`
+	PayloadCid   string `json:"payloadCid"`
+	PieceCid     string `json:"pieceCid"`
+	PieceSize    uint64 `json:"pieceSize"`
+	CarSize      uint64 `json:"carSize"`
+	ManifestPath string `json:"manifestPath"`
+}
+
+// carCommp is the result of car commp
+type carCommp struct {
+	PieceCid  string `json:"pieceCid"`
+	PieceSize uint64 `json:"pieceSize"`
+	CarSize   uint64 `json:"carSize"`
+}
+
+// extractedCar is a CAR restored by car extract
+type extractedCar struct {
+	Path string `json:"path"`
+	*utils.ExtractResult
+}
+
+// aggregatedCar is the aggregate written by car aggregate
+type aggregatedCar struct {
+	Path         string `json:"path"`
+	ManifestPath string `json:"manifestPath"`
+	*utils.AggregateResult
+}
+
+var CarCmd = &cli.Command{
+	Name:  "car",
+	Usage: "Prepare and examine CAR files independently of deal making",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "generate",
+			Usage: "Generate CAR file(s) from a local file/folder or a JSON file list",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "input",
+					Aliases:  []string{"i"},
+					Usage:    "file or folder to pack, or the JSON file list with --file-list (\"-\" reads it from stdin)",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "file-list",
+					Usage: "treat the input as a JSON list of {Path, Size, Start, End} file slices",
+				},
+				&cli.StringFlag{
+					Name:  "parent",
+					Usage: "parent path the file paths are made relative to (default: the input path)",
+				},
+				&cli.StringFlag{
+					Name:    "out-dir",
+					Aliases: []string{"o"},
+					Usage:   "output directory for CAR files",
+					Value:   ".",
+				},
+				&cli.StringFlag{
+					Name:  "tmp-dir",
+					Usage: "directory to copy file slices to while packing",
+				},
+				&cli.Uint64Flag{
+					Name:  "piece-size",
+					Usage: "pad the commp of each CAR up to this piece size",
+				},
+				&cli.Uint64Flag{
+					Name:  "split-size",
+					Usage: "split the input across several CARs that each fit in a piece of this size",
+				},
+			}, dagFlags...),
+			Action: func(c *cli.Context) error {
+				input := c.String("input")
+				parent := c.String("parent")
+				if parent == "" {
+					if c.Bool("file-list") {
+						return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("--parent is required with --file-list"))
+					}
+					parent = input
+				}
+				pieceSize := c.Uint64("piece-size")
+				if pieceSize != 0 && (pieceSize&(pieceSize-1)) != 0 {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("piece-size must be a power of 2"))
+				}
+				dagParams, err := utils.DagParamsFromFlags(c)
+				if err != nil {
+					return err
+				}
+				outDir := c.String("out-dir")
+				if err := os.MkdirAll(outDir, 0755); err != nil {
+					return fmt.Errorf("failed to create output directory: %v", err)
+				}
+
+				carParams := utils.CarParams{
+					Input:     input,
+					OutDir:    outDir,
+					Single:    !c.Bool("file-list"),
+					PieceSize: pieceSize,
+					Parent:    parent,
+					TmpDir:    c.String("tmp-dir"),
+					SplitSize: c.Uint64("split-size"),
+					Dag:       dagParams,
+				}
+
+				var results []utils.Result
+				if carParams.SplitSize > 0 {
+					rs, err := carParams.GenerateSplitCarUtil()
+					if err != nil {
+						return fmt.Errorf("failed to generate CARs: %v", err)
+					}
+					results = rs
+				} else {
+					result, err := carParams.GenerateCarUtil()
+					if err != nil {
+						return fmt.Errorf("failed to generate CAR: %v", err)
+					}
+					results = append(results, result)
+				}
+
+				cars := make([]generatedCar, 0, len(results))
+				for _, result := range results {
+					cars = append(cars, generatedCar{
+						Path:         filepath.Join(outDir, result.PieceCid+".car"),
+						PayloadCid:   result.DataCid,
+						PieceCid:     result.PieceCid,
+						PieceSize:    result.PieceSize,
+						CarSize:      result.CarSize,
+						ManifestPath: result.ManifestPath,
+					})
+				}
+				return printResult(&cars, nil, func(cars *[]generatedCar) {
+					for _, car := range *cars {
+						fmt.Printf("car: %s\n", car.Path)
+						fmt.Printf("  payload cid: %s\n", car.PayloadCid)
+						fmt.Printf("  commp: %s\n", car.PieceCid)
+						fmt.Printf("  piece size: %d\n", car.PieceSize)
+						fmt.Printf("  car size: %d\n", car.CarSize)
+						fmt.Printf("  manifest: %s\n", car.ManifestPath)
+					}
+				})
+			},
+		},
+		{
+			Name:      "inspect",
+			Usage:     "Print the roots, block count and CID map of a CAR file",
+			ArgsUsage: "<car-file>",
+			Action: func(c *cli.Context) error {
+				ctx := context.Background()
+				carPath := c.Args().Get(0)
+				if carPath == "" {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing car-file argument"))
+				}
+
+				info, err := utils.InspectCar(ctx, carPath)
+				if err != nil {
+					return fmt.Errorf("failed to inspect CAR: %v", err)
+				}
+
+				return printResult(info, nil, func(info *utils.CarInfo) {
+					fmt.Printf("roots: %v\n", info.Roots)
+					fmt.Printf("blocks: %d\n", info.BlockCount)
+					fmt.Printf("car size: %d\n", info.CarSize)
+					fmt.Println("cid map:")
+					paths := make([]string, 0, len(info.CidMap))
+					for p := range info.CidMap {
+						paths = append(paths, p)
+					}
+					sort.Strings(paths)
+					for _, p := range paths {
+						v := info.CidMap[p]
+						name := p
+						if v.IsDir {
+							name += "/"
+						}
+						fmt.Printf("  %s %s\n", v.Cid, name)
+					}
+				})
+			},
+		},
+		{
+			Name:      "commp",
+			Usage:     "Compute the piece CID and padded piece size of a CAR file",
+			ArgsUsage: "<car-file>",
+			Flags: []cli.Flag{
+				&cli.Uint64Flag{
+					Name:  "piece-size",
+					Usage: "pad the commp up to this piece size",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				carPath := c.Args().Get(0)
+				if carPath == "" {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing car-file argument"))
+				}
+				pieceSize := c.Uint64("piece-size")
+				if pieceSize != 0 && (pieceSize&(pieceSize-1)) != 0 {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("piece-size must be a power of 2"))
+				}
+
+				stat, err := os.Stat(carPath)
+				if err != nil {
+					return err
+				}
+				commp, paddedSize, err := utils.CarCommp(carPath, pieceSize)
+				if err != nil {
+					return fmt.Errorf("failed to compute commp: %v", err)
+				}
+
+				res := &carCommp{PieceCid: commp.String(), PieceSize: paddedSize, CarSize: uint64(stat.Size())}
+				return printResult(res, nil, func(res *carCommp) {
+					fmt.Printf("commp: %s\n", res.PieceCid)
+					fmt.Printf("piece size: %d\n", res.PieceSize)
+					fmt.Printf("car size: %d\n", res.CarSize)
+				})
+			},
+		},
+		{
+			Name:      "verify",
+			Usage:     "Verify the blocks, DAG and commp of a CAR file against expected values",
+			ArgsUsage: "<car-file>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "manifest",
+					Usage: "manifest to read the expected values from (overridden by the flags below)",
+				},
+				&cli.StringFlag{
+					Name:  "payload-cid",
+					Usage: "expected root CID of the CAR file",
+				},
+				&cli.StringFlag{
+					Name:  "commp",
+					Usage: "expected commp of the CAR file",
+				},
+				&cli.Uint64Flag{
+					Name:  "piece-size",
+					Usage: "expected padded piece size; the commp is padded up to it",
+				},
+				&cli.Uint64Flag{
+					Name:  "car-size",
+					Usage: "expected size of the CAR file",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				ctx := context.Background()
+				carPath := c.Args().Get(0)
+				if carPath == "" {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing car-file argument"))
+				}
+
+				var payloadCid, pieceCid string
+				var pieceSize, carSize uint64
+				if manifestPath := c.String("manifest"); manifestPath != "" {
+					manifest, err := utils.ReadManifest(manifestPath)
+					if err != nil {
+						return err
+					}
+					payloadCid = manifest.PayloadCid
+					pieceCid = manifest.PieceCid
+					pieceSize = manifest.PieceSize
+					carSize = manifest.CarSize
+				}
+				if c.IsSet("payload-cid") {
+					payloadCid = c.String("payload-cid")
+				}
+				if c.IsSet("commp") {
+					pieceCid = c.String("commp")
+				}
+				if c.IsSet("piece-size") {
+					pieceSize = c.Uint64("piece-size")
+				}
+				if c.IsSet("car-size") {
+					carSize = c.Uint64("car-size")
+				}
+				if pieceSize != 0 && (pieceSize&(pieceSize-1)) != 0 {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("piece-size must be a power of 2"))
+				}
+
+				v, err := utils.VerifyCar(ctx, carPath, pieceSize)
+				if err != nil {
+					return err
+				}
+
+				checkErr := v.Check(payloadCid, pieceCid, pieceSize, carSize)
+				return printResult(v, checkErr, func(v *utils.CarVerification) {
+					fmt.Printf("blocks: %d (all match their cids)\n", v.BlockCount)
+					fmt.Printf("dag: complete, %d blocks reached from the root\n", v.DagBlocks)
+					fmt.Printf("payload cid: %s\n", v.PayloadCid)
+					fmt.Printf("commp: %s\n", v.PieceCid)
+					fmt.Printf("piece size: %d (natural: %d)\n", v.PieceSize, v.NaturalPieceSize)
+					fmt.Printf("car size: %d\n", v.CarSize)
+					if checkErr == nil {
+						fmt.Println("CAR verified successfully!")
+					}
+				})
+			},
+		},
+		{
+			Name:      "extract",
+			Usage:     "Restore the files packed in one or more CAR files, reassembling files split across them",
+			ArgsUsage: "<car-file>...",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "out",
+					Aliases: []string{"o"},
+					Usage:   "directory (or file, for a single file) to extract to",
+					Value:   ".",
+				},
+				&cli.StringFlag{
+					Name:  "path",
+					Usage: "only extract this file or directory, relative to the CAR root as in the manifest",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				ctx := context.Background()
+				if c.NArg() == 0 {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing car-file arguments"))
+				}
+
+				var extracted []extractedCar
+				var err error
+				extractor := utils.NewCarExtractor()
+				for _, carPath := range c.Args().Slice() {
+					var res *utils.ExtractResult
+					res, err = extractor.Extract(ctx, carPath, c.String("out"), c.String("path"))
+					if err != nil {
+						err = fmt.Errorf("failed to extract %s: %v", carPath, err)
+						break
+					}
+					extracted = append(extracted, extractedCar{Path: carPath, ExtractResult: res})
+				}
+				if len(extracted) == 0 {
+					return err
+				}
+				return printResult(&extracted, err, func(extracted *[]extractedCar) {
+					for _, car := range *extracted {
+						fmt.Printf("%s: extracted %d files (%d bytes) and %d directories\n", car.Path, car.Files, car.Bytes, car.Dirs)
+					}
+				})
+			},
+		},
+		{
+			Name:      "aggregate",
+			Usage:     "Pack several CAR files into one FRC-0058 data segment aggregate piece",
+			ArgsUsage: "<car-file>...",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "out-dir",
+					Aliases: []string{"o"},
+					Usage:   "output directory for the aggregate and its manifest",
+					Value:   ".",
+				},
+				&cli.Uint64Flag{
+					Name:  "piece-size",
+					Usage: "size of the aggregate piece (default: the smallest piece the CARs fit in)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() == 0 {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing car-file arguments"))
+				}
+				outDir := c.String("out-dir")
+				if err := os.MkdirAll(outDir, 0755); err != nil {
+					return fmt.Errorf("failed to create output directory: %v", err)
+				}
+
+				result, err := utils.AggregateCars(outDir, c.Uint64("piece-size"), c.Args().Slice())
+				if err != nil {
+					return fmt.Errorf("failed to aggregate CARs: %v", err)
+				}
+
+				res := &aggregatedCar{Path: result.Path, ManifestPath: result.ManifestPath, AggregateResult: result}
+				return printResult(res, nil, func(res *aggregatedCar) {
+					fmt.Printf("aggregate: %s\n", res.Path)
+					fmt.Printf("  commp: %s\n", res.PieceCid)
+					fmt.Printf("  piece size: %d\n", res.PieceSize)
+					fmt.Printf("  data size: %d\n", res.DataSize)
+					fmt.Printf("  manifest: %s\n", res.ManifestPath)
+					fmt.Println("sub-pieces:")
+					for _, sp := range res.SubPieces {
+						fmt.Printf("  %s offset %d size %d (%s)\n", sp.PieceCid, sp.Offset, sp.PieceSize, sp.CarPath)
+					}
+				})
+			},
+		},
+	},
+}
+
+// dagFlags select the UnixFS DAG layout of generated CARs, see
+// utils.DagParamsFromFlags.
+var dagFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  "kubo-defaults",
+		Usage: "lay out the DAG like ipfs add does by default (size-262144 chunker, 174 links, CIDv0, no raw leaves); the flags below override it",
+	},
+	&cli.StringFlag{
+		Name:  "chunker",
+		Usage: "chunking algorithm: size-<bytes>, rabin, rabin-<avg>, rabin-<min>-<avg>-<max> or buzhash",
+		Value: utils.DefaultDagParams.Chunker,
+	},
+	&cli.IntFlag{
+		Name:  "max-links",
+		Usage: "maximum number of links per intermediate DAG node",
+		Value: utils.DefaultDagParams.MaxLinks,
+	},
+	&cli.StringFlag{
+		Name:  "layout",
+		Usage: "DAG layout: balanced or trickle",
+		Value: utils.DefaultDagParams.Layout,
+	},
+	&cli.IntFlag{
+		Name:  "cid-version",
+		Usage: "CID version: 0 or 1; 1 turns raw leaves on unless --raw-leaves is set",
+		Value: utils.DefaultDagParams.CidVersion,
+	},
+	&cli.BoolFlag{
+		Name:  "raw-leaves",
+		Usage: "store file chunks as raw blocks rather than UnixFS nodes",
+		Value: utils.DefaultDagParams.RawLeaves,
+	},
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
+	"github.com/eastore-project/fil-deal-wrapper/internal/filecoin"
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	chain_types "github.com/filecoin-project/lotus/chain/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+)
+
+var dealsRepoFlag = &cli.StringFlag{
+	Name:    "repo",
+	Aliases: []string{"R"},
+	Usage:   "Boost client repository directory path (default: ~/.boost-client)",
+	Value:   "~/.boost-client",
+}
+
+// resolvedDeal is a deal proposal deals reconcile found the deal ID of
+type resolvedDeal struct {
+	DealUuid uuid.UUID `json:"dealUuid"`
+	Provider string    `json:"provider"`
+	DealID   uint64    `json:"dealId"`
+}
+
+// reconcileResult is the outcome of deals reconcile
+type reconcileResult struct {
+	Resolved []resolvedDeal `json:"resolved"`
+}
+
+var DealsCmd = &cli.Command{
+	Name:  "deals",
+	Usage: "Query the deal proposals recorded in the local deal store",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List the recorded deal proposals, oldest first",
+			Flags: []cli.Flag{
+				dealsRepoFlag,
+				&cli.StringFlag{
+					Name:  "provider",
+					Usage: "only list the deals of this storage provider",
+				},
+				&cli.StringFlag{
+					Name:  "status",
+					Usage: "only list the deals with this status",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				store, err := filecoin.OpenDealStore(c.String("repo"))
+				if err != nil {
+					return err
+				}
+				defer store.Close()
+
+				recs, err := store.List(context.Background())
+				if err != nil {
+					return err
+				}
+				deals := []*filecoin.DealRecord{}
+				for _, rec := range recs {
+					if c.IsSet("provider") && rec.Provider != c.String("provider") {
+						continue
+					}
+					if c.IsSet("status") && rec.Status != c.String("status") {
+						continue
+					}
+					deals = append(deals, rec)
+				}
+				return printResult(&deals, nil, func(deals *[]*filecoin.DealRecord) {
+					w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+					fmt.Fprintln(w, "DEAL UUID\tPROVIDER\tSTATUS\tDEAL ID\tPIECE CID\tCREATED")
+					for _, rec := range *deals {
+						dealID := "-"
+						if rec.DealID != 0 {
+							dealID = fmt.Sprint(rec.DealID)
+						}
+						fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", rec.DealUuid, rec.Provider, rec.Status, dealID,
+							rec.PieceCid, rec.CreatedAt.Format("2006-01-02 15:04:05"))
+					}
+					w.Flush()
+				})
+			},
+		},
+		{
+			Name:  "reconcile",
+			Usage: "Attach on-chain deal IDs to recorded proposals from the DealNotify events of the contract",
+			Flags: append([]cli.Flag{
+				dealsRepoFlag,
+				&cli.Uint64Flag{
+					Name:  "from-block",
+					Usage: "block to start scanning from (default: after the last scanned block, or the block of the oldest unresolved proposal)",
+				},
+			}, commonReadFlags...),
+			Action: func(c *cli.Context) error {
+				ctx := context.Background()
+				client, err := eth.NewETHClient(ctx, c)
+				if err != nil {
+					return err
+				}
+				store, err := filecoin.OpenDealStore(c.String("repo"))
+				if err != nil {
+					return err
+				}
+				defer store.Close()
+
+				resolved, err := filecoin.ReconcileDeals(ctx, store, client, c.Uint64("from-block"))
+				if err != nil {
+					return err
+				}
+				res := &reconcileResult{Resolved: []resolvedDeal{}}
+				for _, rec := range resolved {
+					res.Resolved = append(res.Resolved, resolvedDeal{DealUuid: rec.DealUuid, Provider: rec.Provider, DealID: rec.DealID})
+				}
+				return printResult(res, nil, func(res *reconcileResult) {
+					for _, deal := range res.Resolved {
+						fmt.Printf("deal %s with %s: deal id %d\n", deal.DealUuid, deal.Provider, deal.DealID)
+					}
+					fmt.Printf("resolved %d deal ids\n", len(res.Resolved))
+				})
+			},
+		},
+		{
+			Name:      "show",
+			Usage:     "Show everything recorded about a deal proposal",
+			ArgsUsage: "<deal uuid>",
+			Flags:     []cli.Flag{dealsRepoFlag},
+			Action: func(c *cli.Context) error {
+				dealUuid, err := uuid.Parse(c.Args().Get(0))
+				if err != nil {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid deal uuid %q: %w", c.Args().Get(0), err))
+				}
+				store, err := filecoin.OpenDealStore(c.String("repo"))
+				if err != nil {
+					return err
+				}
+				defer store.Close()
+
+				rec, err := store.Get(context.Background(), dealUuid)
+				if err != nil {
+					return err
+				}
+				return printResult(rec, nil, func(rec *filecoin.DealRecord) {
+					proposal := rec.Params.ClientDealProposal.Proposal
+					fmt.Printf("deal uuid: %s\n", rec.DealUuid)
+					fmt.Printf("status: %s\n", rec.Status)
+					if rec.Message != "" {
+						fmt.Printf("message: %s\n", rec.Message)
+					}
+					if rec.Checkpoint != "" {
+						fmt.Printf("checkpoint: %s\n", rec.Checkpoint)
+					}
+					if rec.SealingStatus != "" {
+						fmt.Printf("sealing status: %s\n", rec.SealingStatus)
+					}
+					if rec.PublishCid != "" {
+						fmt.Printf("publish cid: %s\n", rec.PublishCid)
+					}
+					if rec.DealID != 0 {
+						fmt.Printf("deal id: %d\n", rec.DealID)
+					}
+					fmt.Printf("storage provider: %s\n", rec.Provider)
+					fmt.Printf("client contract address: %s\n", rec.Client)
+					fmt.Printf("contract: %s\n", rec.Contract)
+					fmt.Printf("signer wallet: %s\n", rec.Signer)
+					fmt.Printf("payload cid: %s\n", rec.PayloadCid)
+					fmt.Printf("commp: %s\n", rec.PieceCid)
+					fmt.Printf("piece size: %d\n", rec.PieceSize)
+					if rec.Params.IsOffline {
+						fmt.Println("offline: true")
+					} else {
+						fmt.Printf("url: %s\n", rec.TransferURL)
+						fmt.Printf("car size: %d\n", rec.CarSize)
+					}
+					fmt.Printf("verified: %t\n", proposal.VerifiedDeal)
+					fmt.Printf("start epoch: %d\n", proposal.StartEpoch)
+					fmt.Printf("end epoch: %d\n", proposal.EndEpoch)
+					fmt.Printf("storage price per epoch: %s\n", chain_types.FIL(proposal.StoragePricePerEpoch).Short())
+					fmt.Printf("provider collateral: %s\n", chain_types.FIL(proposal.ProviderCollateral).Short())
+					fmt.Printf("created: %s\n", rec.CreatedAt.Format("2006-01-02 15:04:05"))
+					fmt.Printf("updated: %s\n", rec.UpdatedAt.Format("2006-01-02 15:04:05"))
+				})
+			},
+		},
+	},
+}
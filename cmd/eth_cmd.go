@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	keystoreFlag = &cli.StringFlag{
+		Name:  "keystore",
+		Usage: "Directory of the encrypted keystore holding --account",
+		Value: eth.DefaultKeystoreDir,
+	}
+	accountFlag = &cli.StringFlag{
+		Name:  "account",
+		Usage: "Address of the keystore account signing transactions (default: the only account of the keystore)",
+	}
+	passwordFileFlag = &cli.StringFlag{
+		Name:  "password-file",
+		Usage: "File holding the keystore password (default: $" + eth.KeystorePasswordEnv + ", else prompted)",
+	}
+	privateKeyFileFlag = &cli.StringFlag{
+		Name:  "private-key-file",
+		Usage: "File holding the hex private key signing transactions",
+	}
+)
+
+// keyAccount is an account of the keystore
+type keyAccount struct {
+	Address common.Address `json:"address"`
+	Path    string         `json:"path"`
+}
+
+// exportedKey is the result of eth key export
+type exportedKey struct {
+	Address common.Address  `json:"address"`
+	Key     json.RawMessage `json:"key"`
+}
+
+func newKeyAccount(account accounts.Account) keyAccount {
+	return keyAccount{Address: account.Address, Path: account.URL.Path}
+}
+
+func printKeyAccount(account *keyAccount) {
+	fmt.Printf("address: %s\n", account.Address.Hex())
+	fmt.Printf("key file: %s\n", account.Path)
+}
+
+var EthCmd = &cli.Command{
+	Name:  "eth",
+	Usage: "Manage the Ethereum accounts signing contract transactions",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "key",
+			Usage: "Manage the accounts of the encrypted keystore",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "create",
+					Usage: "Create an account with a new random key",
+					Flags: []cli.Flag{keystoreFlag, passwordFileFlag},
+					Action: func(c *cli.Context) error {
+						ks, err := eth.OpenKeystore(c.String("keystore"))
+						if err != nil {
+							return err
+						}
+						password, err := eth.ReadPassword(c.String("password-file"), "Password of the new account: ", true)
+						if err != nil {
+							return err
+						}
+						account, err := ks.NewAccount(password)
+						if err != nil {
+							return fmt.Errorf("failed to create account: %v", err)
+						}
+						res := newKeyAccount(account)
+						return printResult(&res, nil, printKeyAccount)
+					},
+				},
+				{
+					Name:      "import",
+					Usage:     "Encrypt a hex private key into the keystore",
+					ArgsUsage: "<private key file>",
+					Flags:     []cli.Flag{keystoreFlag, passwordFileFlag},
+					Action: func(c *cli.Context) error {
+						if c.Args().Len() < 1 {
+							return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("private key file argument is required"))
+						}
+						privateKey, _, err := eth.LoadPrivateKeyFile(c.Args().Get(0))
+						if err != nil {
+							return err
+						}
+						ks, err := eth.OpenKeystore(c.String("keystore"))
+						if err != nil {
+							return err
+						}
+						password, err := eth.ReadPassword(c.String("password-file"), "Password of the imported account: ", true)
+						if err != nil {
+							return err
+						}
+						account, err := ks.ImportECDSA(privateKey, password)
+						if err != nil {
+							return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("failed to import key: %v", err))
+						}
+						res := newKeyAccount(account)
+						return printResult(&res, nil, printKeyAccount)
+					},
+				},
+				{
+					Name:  "list",
+					Usage: "List the accounts of the keystore",
+					Flags: []cli.Flag{keystoreFlag},
+					Action: func(c *cli.Context) error {
+						ks, err := eth.OpenKeystore(c.String("keystore"))
+						if err != nil {
+							return err
+						}
+						res := []keyAccount{}
+						for _, account := range ks.Accounts() {
+							res = append(res, newKeyAccount(account))
+						}
+						return printResult(&res, nil, func(res *[]keyAccount) {
+							w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+							fmt.Fprintln(w, "ADDRESS\tKEY FILE")
+							for _, account := range *res {
+								fmt.Fprintf(w, "%s\t%s\n", account.Address.Hex(), account.Path)
+							}
+							w.Flush()
+						})
+					},
+				},
+				{
+					Name:      "export",
+					Usage:     "Print the encrypted JSON key of an account, for use with other tools",
+					ArgsUsage: "<address>",
+					Flags:     []cli.Flag{keystoreFlag, passwordFileFlag},
+					Action: func(c *cli.Context) error {
+						ks, err := eth.OpenKeystore(c.String("keystore"))
+						if err != nil {
+							return err
+						}
+						account, err := eth.FindAccount(ks, c.Args().Get(0))
+						if err != nil {
+							return err
+						}
+						password, err := eth.ReadPassword(c.String("password-file"), fmt.Sprintf("Password of %s: ", account.Address.Hex()), false)
+						if err != nil {
+							return err
+						}
+						keyJSON, err := ks.Export(account, password, password)
+						if err != nil {
+							return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("failed to export key of %s: %v", account.Address.Hex(), err))
+						}
+						res := exportedKey{Address: account.Address, Key: keyJSON}
+						return printResult(&res, nil, func(res *exportedKey) {
+							fmt.Println(string(res.Key))
+						})
+					},
+				},
+			},
+		},
+	},
+}
@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/filecoin"
 	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
+	chain_types "github.com/filecoin-project/lotus/chain/types"
 	"github.com/urfave/cli/v2"
 )
 
@@ -28,31 +33,83 @@ var FilCmd = &cli.Command{
 					Name:  "http-headers",
 					Usage: "http headers to be passed with the request (e.g key=value)",
 				},
+				&cli.BoolFlag{
+					Name:  "skip-url-check",
+					Usage: "do not check that the CAR url answers with the car size before proposing the deal",
+				},
+				&cli.BoolFlag{
+					Name:  "verify-url-commp",
+					Usage: "also download the whole CAR from the url and check its commp before proposing the deal",
+				},
 				&cli.Uint64Flag{
-					Name:     "car-size",
-					Usage:    "size of the CAR file: required for online deals",
-					Required: true,
+					Name:  "car-size",
+					Usage: "size of the CAR file: required for online deals unless --from-url is set",
 				},
-			}, dealFlags...),
+				&cli.BoolFlag{
+					Name:  "from-url",
+					Usage: "download the CAR from the url once to derive the commp, piece size, payload cid and car size; values also given as flags must match",
+				},
+			}, append(pieceFlags(false), dealFlags...)...),
 			Action: func(cctx *cli.Context) error {
-				return filecoin.DealCmdAction(cctx, true)
+				summary, err := filecoin.DealCmdAction(cctx, true)
+				return printResult(summary, err, printDealSummary)
 			},
 		},
 		{
 			Name:  "local-deal",
 			Usage: "Make deal from any local file/folder with wrappedeal",
-			Flags: localDealFlags,
+			Flags: append(append(localDealFlags, hostFlags...), dagFlags...),
 			Action: func(cctx *cli.Context) error {
-				return filecoin.LocalDealCmdAction(cctx, true)
-
+				summary, err := filecoin.LocalDealCmdAction(cctx, true)
+				return printResult(summary, err, printDealSummary)
 			},
 		},
 		{
 			Name:  "offline-deal",
 			Usage: "Make an offline deal with wrappedeal",
-			Flags: dealFlags,
+			Flags: append(pieceFlags(true), dealFlags...),
+			Action: func(cctx *cli.Context) error {
+				summary, err := filecoin.DealCmdAction(cctx, false)
+				return printResult(summary, err, printDealSummary)
+			},
+		},
+		{
+			Name:      "deal-status",
+			Usage:     "Ask the storage provider for the status of a deal over the boost deal status protocol",
+			ArgsUsage: "<deal uuid>",
+			Description: "Boost verifies the request signature against the account key of the deal client. When that client\n" +
+				"is a contract, as for the deals made through the wrapper contract, no wallet can sign for it and the\n" +
+				"command fails without asking the provider. Find the on-chain deal ID of such deals with\n" +
+				"`deals reconcile` and look them up with `lotus state get-deal <deal id>` (StateMarketStorageDeal).",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "provider",
+					Usage: "storage provider on-chain address (default: the provider recorded for the deal)",
+				},
+				&cli.StringFlag{
+					Name:  "wallet",
+					Usage: "wallet address that signed the deal proposal (default: the signer recorded for the deal)",
+				},
+				&cli.BoolFlag{
+					Name:  "watch",
+					Usage: "poll the status until the deal is active or has failed",
+				},
+				&cli.DurationFlag{
+					Name:  "interval",
+					Usage: "time between two polls with --watch",
+					Value: time.Minute,
+				},
+				&cli.StringFlag{
+					Name:    "repo",
+					Aliases: []string{"R"},
+					Usage:   "Boost client repository directory path (default: ~/.boost-client)",
+					Value:   "~/.boost-client",
+				},
+			},
 			Action: func(cctx *cli.Context) error {
-				return filecoin.DealCmdAction(cctx, false)
+				return filecoin.DealStatusCmdAction(cctx, func(res *filecoin.DealStatusResult) error {
+					return printResult(res, nil, printDealStatus)
+				})
 			},
 		},
 		{
@@ -84,7 +141,10 @@ var FilCmd = &cli.Command{
 				if err != nil {
 					return fmt.Errorf("failed to expand repo path: %v", err)
 				}
-				return filecoin.GetEthAddr(ctx, filecoinAddrStr, repoPath)
+				res, err := filecoin.GetEthAddr(ctx, filecoinAddrStr, repoPath)
+				return printResult(res, err, func(res *filecoin.EthAddrResult) {
+					fmt.Println("Derived Ethereum Address:", res.EthAddress.Hex())
+				})
 			},
 		},
 		{
@@ -106,32 +166,46 @@ var FilCmd = &cli.Command{
 				},
 			},
 			Action: func(cctx *cli.Context) error {
-				return filecoin.GetActorIdAction(cctx)
+				res, err := filecoin.GetActorIdAction(cctx)
+				return printResult(res, err, func(res *filecoin.ActorIdResult) {
+					fmt.Println("Actor ID:", res.ActorId)
+				})
 			},
 		},
 	},
 }
 
+// pieceFlags describe the CAR of a deal. They are optional for online deals
+// that derive them from the CAR url.
+func pieceFlags(required bool) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "commp",
+			Usage:    "commp of the CAR file",
+			Required: required,
+		},
+		&cli.Uint64Flag{
+			Name:     "piece-size",
+			Usage:    "size of the CAR file as a padded piece",
+			Required: required,
+		},
+		&cli.StringFlag{
+			Name:     "payload-cid",
+			Usage:    "root CID of the CAR file",
+			Required: required,
+		},
+	}
+}
+
 var dealFlags = []cli.Flag{
-	&cli.StringFlag{
+	&cli.StringSliceFlag{
 		Name:     "provider",
-		Usage:    "storage provider on-chain address",
-		Required: true,
-	},
-	&cli.StringFlag{
-		Name:     "commp",
-		Usage:    "commp of the CAR file",
-		Required: true,
-	},
-	&cli.Uint64Flag{
-		Name:     "piece-size",
-		Usage:    "size of the CAR file as a padded piece",
+		Usage:    "on-chain address of a storage provider to propose the deal to; repeat or separate with commas for replicas",
 		Required: true,
 	},
-	&cli.StringFlag{
-		Name:     "payload-cid",
-		Usage:    "root CID of the CAR file",
-		Required: true,
+	&cli.IntFlag{
+		Name:  "replicas",
+		Usage: "number of accepted deals wanted, taking the providers as a pool of candidates tried in order (default: one per provider)",
 	},
 	&cli.IntFlag{
 		Name:  "start-epoch-head-offset",
@@ -174,6 +248,19 @@ var dealFlags = []cli.Flag{
 		Usage: "indicates that deal index should not be announced to the IPNI(Network Indexer)",
 		Value: false,
 	},
+	&cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "build and sign the deal proposal, print the deal params as JSON and write the signed proposal as CBOR without contacting the storage provider",
+	},
+	&cli.StringFlag{
+		Name:  "dry-run-dir",
+		Usage: "directory to write the <deal uuid>.cbor signed proposals of --dry-run to",
+		Value: ".",
+	},
+	&cli.BoolFlag{
+		Name:  "skip-preflight",
+		Usage: "do not check the whitelist, provider registration, contract balance and datacap before proposing the deal",
+	},
 	&cli.StringFlag{
 		Name:     "repo",
 		Aliases:  []string{"R"},
@@ -195,20 +282,15 @@ var localDealFlags = []cli.Flag{
 		Usage:    "Path to the file or folder to make the deal",
 		Required: true,
 	},
-	&cli.BoolFlag{
-		Name:  "lighthouse",
-		Usage: "Use Lighthouse as a buffer",
-		Value: false,
-	},
-	&cli.StringFlag{
-		Name:  "apikey",
-		Usage: "API key for Lighthouse (overrides .env)",
-	},
-	&cli.StringFlag{
+	&cli.StringSliceFlag{
 		Name:     "provider",
-		Usage:    "storage provider on-chain address",
+		Usage:    "on-chain address of a storage provider to propose the deal to; repeat or separate with commas for replicas",
 		Required: true,
 	},
+	&cli.IntFlag{
+		Name:  "replicas",
+		Usage: "number of accepted deals wanted, taking the providers as a pool of candidates tried in order (default: one per provider)",
+	},
 	&cli.StringFlag{
 		Name:  "payload-cid",
 		Usage: "root CID of the CAR file",
@@ -262,6 +344,14 @@ var localDealFlags = []cli.Flag{
 		Name:  "http-headers",
 		Usage: "http headers to be passed with the request (e.g key=value)",
 	},
+	&cli.BoolFlag{
+		Name:  "skip-url-check",
+		Usage: "do not check that the CAR url answers with the car size before proposing the deal",
+	},
+	&cli.BoolFlag{
+		Name:  "verify-url-commp",
+		Usage: "also download the whole CAR from the url and check its commp before proposing the deal",
+	},
 	&cli.Uint64Flag{
 		Name:  "piece-size",
 		Usage: "size of the CAR file as a padded piece",
@@ -271,6 +361,19 @@ var localDealFlags = []cli.Flag{
 		Usage: "output directory for CAR files",
 		Value: "/tmp",
 	},
+	&cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "build and sign the deal proposal, print the deal params as JSON and write the signed proposal as CBOR without contacting the storage provider",
+	},
+	&cli.StringFlag{
+		Name:  "dry-run-dir",
+		Usage: "directory to write the <deal uuid>.cbor signed proposals of --dry-run to",
+		Value: ".",
+	},
+	&cli.BoolFlag{
+		Name:  "skip-preflight",
+		Usage: "do not check the whitelist, provider registration, contract balance and datacap before proposing the deal",
+	},
 	&cli.StringFlag{
 		Name:     "repo",
 		Aliases:  []string{"R"},
@@ -285,3 +388,118 @@ var localDealFlags = []cli.Flag{
 		Required: true,
 	},
 }
+
+// hostFlags select and configure the backend that hosts the CARs of local
+// deals, see utils.CarHostFromFlags.
+var hostFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "host",
+		Usage: "backend to host the CAR on for the storage provider: lighthouse, s3, local or webdav (default: use --http-url)",
+	},
+	&cli.StringFlag{
+		Name:  "apikey",
+		Usage: "API key for Lighthouse (overrides .env)",
+	},
+	&cli.StringFlag{
+		Name:  "s3-endpoint",
+		Usage: "base URL of the S3-compatible service, e.g. http://localhost:9000 for MinIO",
+	},
+	&cli.StringFlag{
+		Name:  "s3-region",
+		Usage: "region of the S3 bucket",
+		Value: "us-east-1",
+	},
+	&cli.StringFlag{
+		Name:  "s3-bucket",
+		Usage: "S3 bucket to upload the CAR to",
+	},
+	&cli.StringFlag{
+		Name:  "s3-prefix",
+		Usage: "key prefix of the uploaded CAR in the S3 bucket",
+	},
+	&cli.DurationFlag{
+		Name:  "s3-url-expiry",
+		Usage: "validity of the presigned URL given to the storage provider (at most 168h)",
+		Value: 7 * 24 * time.Hour,
+	},
+	&cli.StringFlag{
+		Name:  "serve-url",
+		Usage: "public URL of the \"serve start\" server of serve-dir, for the local host",
+	},
+	&cli.StringFlag{
+		Name:  "serve-dir",
+		Usage: "directory served by \"serve start\" to move the CAR to, for the local host (default: out-dir)",
+	},
+	&cli.StringFlag{
+		Name:  "webdav-url",
+		Usage: "URL of the WebDAV (or any PUT) directory to upload the CAR to",
+	},
+	&cli.StringFlag{
+		Name:  "webdav-public-url",
+		Usage: "URL of the same directory for the storage provider to download from (default: webdav-url)",
+	},
+}
+
+// printDealSummary prints the outcome of a deal command as text
+func printDealSummary(s *filecoin.DealSummary) {
+	if s.DryRun {
+		for _, res := range s.Deals {
+			out, _ := json.MarshalIndent(res.Params, "", "  ")
+			fmt.Println(string(out))
+			fmt.Println("signed deal proposal not sent (dry run)", "provider", res.Provider, "cbor", res.ProposalFile)
+		}
+		return
+	}
+
+	msg := "sent deal proposals"
+	if s.Offline {
+		msg += " for offline deal"
+	}
+	msg += "\n"
+	msg += fmt.Sprintf("  client contract address: %s\n", s.Client)
+	msg += fmt.Sprintf("  signer wallet: %s\n", s.Signer)
+	msg += fmt.Sprintf("  payload cid: %s\n", s.PayloadCid)
+	if !s.Offline {
+		msg += fmt.Sprintf("  url: %s\n", s.URL)
+	}
+	msg += fmt.Sprintf("  commp: %s\n", s.PieceCid)
+	msg += fmt.Sprintf("  start epoch: %d\n", s.StartEpoch)
+	msg += fmt.Sprintf("  end epoch: %d\n", s.EndEpoch)
+	msg += fmt.Sprintf("  provider collateral: %s\n", chain_types.FIL(s.ProviderCollateral).Short())
+	fmt.Println(msg)
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tSTATUS\tDEAL UUID\tMESSAGE")
+	for _, res := range s.Deals {
+		status := "rejected"
+		if res.Accepted {
+			status = "accepted"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", res.Provider, status, res.DealUuid, res.Message)
+	}
+	w.Flush()
+}
+
+// printDealStatus prints the status of a deal as text
+func printDealStatus(res *filecoin.DealStatusResult) {
+	msg := "got deal status response\n"
+	msg += fmt.Sprintf("  deal uuid: %s\n", res.DealUuid)
+	msg += fmt.Sprintf("  checkpoint: %s\n", res.Checkpoint)
+	msg += fmt.Sprintf("  deal status: %s\n", res.Status)
+	if !res.IsOffline {
+		msg += fmt.Sprintf("  transfer: %d/%d bytes\n", res.BytesReceived, res.TransferSize)
+	}
+	if res.SealingStatus != "" {
+		msg += fmt.Sprintf("  sealing status: %s\n", res.SealingStatus)
+	}
+	if res.Error != "" {
+		msg += fmt.Sprintf("  error: %s\n", res.Error)
+	}
+	if res.PublishCid != "" {
+		msg += fmt.Sprintf("  publish cid: %s\n", res.PublishCid)
+	}
+	if res.ChainDealID != 0 {
+		msg += fmt.Sprintf("  chain deal id: %d\n", res.ChainDealID)
+	}
+	fmt.Println(msg)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/urfave/cli/v2"
+)
+
+// OutputFlag selects how the results and errors of every command are printed
+var OutputFlag = &cli.StringFlag{
+	Name:  "output",
+	Usage: "output format of results and errors: text or json",
+	Value: "text",
+}
+
+var (
+	jsonOutput bool
+	// resultOut is where results are written. In json mode os.Stdout is
+	// pointed at stderr, so that the progress messages printed along the
+	// way do not mix with the JSON on stdout.
+	resultOut io.Writer = os.Stdout
+)
+
+// SetupOutput is the Before hook of the app that applies --output
+func SetupOutput(c *cli.Context) error {
+	switch c.String("output") {
+	case "text":
+	case "json":
+		jsonOutput = true
+		resultOut = os.Stdout
+		os.Stdout = os.Stderr
+		c.App.Writer = os.Stderr
+	default:
+		return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("unknown output format %q, use text or json", c.String("output")))
+	}
+	return nil
+}
+
+// resultError is the error of a command that still produced a result, such
+// as a reverted transaction or a deal only some providers accepted
+type resultError struct {
+	result interface{}
+	err    error
+}
+
+func (e *resultError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resultError) Unwrap() error {
+	return e.err
+}
+
+// printResult prints res, the result of a command that failed with err when
+// err is not nil, and returns err. In json mode res is written as a single
+// line of JSON, or along with err by PrintError; otherwise text prints it.
+func printResult[T any](res *T, err error, text func(res *T)) error {
+	if res == nil {
+		return err
+	}
+	if !jsonOutput {
+		text(res)
+		return err
+	}
+	if err != nil {
+		return &resultError{result: res, err: err}
+	}
+	return json.NewEncoder(resultOut).Encode(res)
+}
+
+// jsonError is how errors are printed in json mode
+type jsonError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Result interface{} `json:"result,omitempty"`
+}
+
+// PrintError prints the error the app failed with. In json mode it is
+// written to stdout as {"error": {"code": ..., "message": ...}}, with the
+// result of the command when there is one.
+func PrintError(err error) {
+	if !jsonOutput {
+		log.Println(err)
+		return
+	}
+	var out jsonError
+	out.Error.Code = types.ErrorCode(err)
+	out.Error.Message = err.Error()
+	if re, ok := err.(*resultError); ok {
+		out.Result = re.result
+	}
+	if err := json.NewEncoder(resultOut).Encode(out); err != nil {
+		log.Println(err)
+	}
+}
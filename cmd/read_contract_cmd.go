@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/contract"
 	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 
 	"github.com/urfave/cli/v2"
 )
@@ -23,8 +25,7 @@ var commonReadFlags = []cli.Flag{
 	&cli.StringFlag{
 		Name:    "abi-path",
 		Aliases: []string{"b"},
-		Usage:   "Path to the contract ABI file",
-		Value:   "./contracts/out/MarketDealWrapper.sol/MarketDealWrapper.json",
+		Usage:   "Path to a forge build output of the MarketDealWrapper contract to use instead of the embedded ABI",
 	},
 	&cli.StringFlag{
 		Name:    "rpc-url",
@@ -47,11 +48,11 @@ var ReadContractCmd = &cli.Command{
 				ctx := context.Background()
 				actorIdStr := c.Args().Get(0)
 				if actorIdStr == "" {
-					return fmt.Errorf("missing actor-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing actor-id argument"))
 				}
 				actorId, err := strconv.ParseUint(actorIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid actor-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid actor-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -62,8 +63,11 @@ var ReadContractCmd = &cli.Command{
 					return err
 				}
 
-				_, err = contract.GetSpFromIdAction(ctx, client, actorId)
-				return err
+				res, err := contract.GetSpFromIdAction(ctx, client, actorId)
+				return printResult(res, err, func(res *contract.StorageProviderParams) {
+					out, _ := json.MarshalIndent(res, "", "  ")
+					fmt.Println(string(out))
+				})
 			},
 		},
 
@@ -77,11 +81,11 @@ var ReadContractCmd = &cli.Command{
 				ctx := context.Background()
 				minerIdStr := c.Args().Get(0)
 				if minerIdStr == "" {
-					return fmt.Errorf("missing miner-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing miner-id argument"))
 				}
 				minerId, err := strconv.ParseUint(minerIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid miner-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid miner-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -92,7 +96,10 @@ var ReadContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.GetDealsFromMinerIdAction(ctx, client, minerId)
+				res, err := contract.GetDealsFromMinerIdAction(ctx, client, minerId)
+				return printResult(res, err, func(res *contract.MinerDeals) {
+					fmt.Printf("Deal IDs for Miner ID %d: %v\n", res.MinerId, res.DealIds)
+				})
 			},
 		},
 		{
@@ -105,11 +112,11 @@ var ReadContractCmd = &cli.Command{
 				ctx := context.Background()
 				actorIdStr := c.Args().Get(0)
 				if actorIdStr == "" {
-					return fmt.Errorf("missing actor-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing actor-id argument"))
 				}
 				actorId, err := strconv.ParseUint(actorIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid actor-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid actor-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -119,7 +126,10 @@ var ReadContractCmd = &cli.Command{
 				if err != nil {
 					return err
 				}
-				return contract.IsWhitelistedAction(ctx, client, actorId)
+				res, err := contract.IsWhitelistedAction(ctx, client, actorId)
+				return printResult(res, err, func(res *contract.WhitelistStatus) {
+					fmt.Printf("Is actor-id %d whitelisted? %t\n", res.ActorId, res.IsWhitelisted)
+				})
 			},
 		},
 		{
@@ -132,11 +142,11 @@ var ReadContractCmd = &cli.Command{
 				ctx := context.Background()
 				dealIdStr := c.Args().Get(0)
 				if dealIdStr == "" {
-					return fmt.Errorf("missing deal-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing deal-id argument"))
 				}
 				dealId, err := strconv.ParseUint(dealIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid deal-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid deal-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -147,7 +157,10 @@ var ReadContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.GetSpFundsForDealAction(ctx, client, dealId)
+				res, err := contract.GetSpFundsForDealAction(ctx, client, dealId)
+				return printResult(res, err, func(res *contract.DealFunds) {
+					fmt.Printf("Currently claimable SP funds for Deal ID %d: %s\n", res.DealId, res.Funds)
+				})
 			},
 		},
 		{
@@ -161,17 +174,20 @@ var ReadContractCmd = &cli.Command{
 				token := c.Args().Get(0)
 				actorIdStr := c.Args().Get(1)
 				if token == "" || actorIdStr == "" {
-					return fmt.Errorf("missing token or actor-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing token or actor-id argument"))
 				}
 				actorId, err := strconv.ParseUint(actorIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid actor-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid actor-id: %v", err))
 				}
 				client, err := eth.NewETHClient(ctx, c)
 				if err != nil {
 					return err
 				}
-				return contract.GetTokenFundsForSPAction(ctx, client, token, actorId)
+				res, err := contract.GetTokenFundsForSPAction(ctx, client, token, actorId)
+				return printResult(res, err, func(res *contract.TokenFunds) {
+					fmt.Printf("Currently claimable SP funds for Token %s and Actor ID %d: %s\n", res.Token.Hex(), res.ActorId, res.Funds)
+				})
 			},
 		},
 	},
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
+
+	"github.com/urfave/cli/v2"
+)
+
+var serveDirFlag = &cli.StringFlag{
+	Name:     "dir",
+	Aliases:  []string{"d"},
+	Usage:    "directory holding the <commp>.car files to serve",
+	Required: true,
+}
+
+var ServeCmd = &cli.Command{
+	Name:  "serve",
+	Usage: "Serve generated CAR files over HTTP to storage providers for online deals",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "start",
+			Usage: "Start the HTTP server; requests need a bearer token minted for the CAR they fetch",
+			Flags: []cli.Flag{
+				serveDirFlag,
+				&cli.StringFlag{
+					Name:    "listen",
+					Aliases: []string{"l"},
+					Usage:   "address to listen on",
+					Value:   ":8080",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				server := &utils.CarServer{Dir: c.String("dir")}
+				fmt.Printf("serving CAR files from %s on %s\n", server.Dir, c.String("listen"))
+				return http.ListenAndServe(c.String("listen"), server)
+			},
+		},
+		{
+			Name:      "token",
+			Usage:     "Mint a bearer token giving a deal access to one served CAR",
+			ArgsUsage: "<commp>",
+			Flags: []cli.Flag{
+				serveDirFlag,
+				&cli.StringFlag{
+					Name:  "provider",
+					Usage: "storage provider the token is meant for",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				commp := c.Args().Get(0)
+				if commp == "" {
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing commp argument"))
+				}
+				token, err := utils.MintServeToken(c.String("dir"), commp, c.String("provider"))
+				if err != nil {
+					return fmt.Errorf("failed to mint token: %v", err)
+				}
+				return printResult(token, nil, func(token *utils.ServeToken) {
+					fmt.Printf("token: %s\n", token.Token)
+					fmt.Printf("http header: %s\n", token.AuthHeader())
+				})
+			},
+		},
+		{
+			Name:  "stats",
+			Usage: "Print the requests and bytes served for every token",
+			Flags: []cli.Flag{serveDirFlag},
+			Action: func(c *cli.Context) error {
+				tokens, err := utils.ListServeTokens(c.String("dir"))
+				if err != nil {
+					return err
+				}
+				if tokens == nil {
+					tokens = []utils.ServeToken{}
+				}
+				return printResult(&tokens, nil, func(tokens *[]utils.ServeToken) {
+					for _, t := range *tokens {
+						fmt.Printf("%s %s.car\n", t.Token, t.PieceCid)
+						if t.Provider != "" {
+							fmt.Printf("  provider: %s\n", t.Provider)
+						}
+						fmt.Printf("  created: %s\n", t.CreatedAt.Format("2006-01-02 15:04:05"))
+						fmt.Printf("  requests: %d\n", t.Requests)
+						fmt.Printf("  bytes served: %d\n", t.BytesServed)
+					}
+				})
+			},
+		},
+	},
+}
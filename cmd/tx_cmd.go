@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+var txHashRegexp = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+var txFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "private-key",
+		Aliases: []string{"k"},
+		Usage:   "Private key of the account that sent the transaction (overrides .env)",
+	},
+	&cli.StringFlag{
+		Name:    "rpc-url",
+		Aliases: []string{"r"},
+		Usage:   "RPC URL for the Ethereum node (overrides .env)",
+	},
+	privateKeyFileFlag,
+	keystoreFlag,
+	accountFlag,
+	passwordFileFlag,
+	maxFeeFlag,
+	maxPriorityFeeFlag,
+	gasLimitMultiplierFlag,
+	legacyTxFlag,
+	confirmationsFlag,
+	receiptTimeoutFlag,
+}
+
+var TxCmd = &cli.Command{
+	Name:  "tx",
+	Usage: "Manage the pending transactions of the account",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "speedup",
+			Usage:     "Resend a pending transaction with higher fees",
+			ArgsUsage: "<tx hash>",
+			Flags:     txFlags,
+			Action: func(c *cli.Context) error {
+				return replaceTx(c, (*eth.TxManager).SpeedUp, "Transaction sped up!")
+			},
+		},
+		{
+			Name:      "cancel",
+			Usage:     "Replace a pending transaction with an empty transfer to the account itself",
+			ArgsUsage: "<tx hash>",
+			Flags:     txFlags,
+			Action: func(c *cli.Context) error {
+				return replaceTx(c, (*eth.TxManager).Cancel, "Transaction cancelled!")
+			},
+		},
+	},
+}
+
+// replaceTx replaces the pending transaction given as argument with the one
+// built by replace and waits for it
+func replaceTx(c *cli.Context, replace func(*eth.TxManager, context.Context, common.Hash) (*ethTypes.Transaction, error), success string) error {
+	ctx := context.Background()
+	if !txHashRegexp.MatchString(c.Args().Get(0)) {
+		return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid transaction hash %q", c.Args().Get(0)))
+	}
+	txHash := common.HexToHash(c.Args().Get(0))
+
+	client, err := eth.NewETHClient(ctx, c)
+	if err != nil {
+		return err
+	}
+	txs := eth.NewTxManager(client, c.Duration("receipt-timeout"), c.Uint64("confirmations"))
+	tx, err := replace(txs, ctx, txHash)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Replacement transaction sent: %s\n", tx.Hash().Hex())
+	fmt.Println("Waiting for confirmation...")
+
+	res, err := txs.Wait(ctx, tx)
+	return printTxResult(res, err, success)
+}
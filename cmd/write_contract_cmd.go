@@ -4,15 +4,47 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/contract"
 	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
+var (
+	maxFeeFlag = &cli.StringFlag{
+		Name:  "max-fee",
+		Usage: "Maximum fee per gas in attoFIL; caps the gas price of legacy transactions (default: twice the base fee plus the priority fee)",
+	}
+	maxPriorityFeeFlag = &cli.StringFlag{
+		Name:  "max-priority-fee",
+		Usage: "Maximum priority fee per gas in attoFIL (default: suggested by the node)",
+	}
+	gasLimitMultiplierFlag = &cli.Float64Flag{
+		Name:  "gas-limit-multiplier",
+		Usage: "Multiplier applied to the estimated gas limit",
+		Value: 1,
+	}
+	legacyTxFlag = &cli.BoolFlag{
+		Name:  "legacy-tx",
+		Usage: "Send legacy transactions at the gas price suggested by the node instead of EIP-1559 ones",
+	}
+	confirmationsFlag = &cli.Uint64Flag{
+		Name:  "confirmations",
+		Usage: "Number of blocks, from the one including the transaction, to wait for",
+		Value: 1,
+	}
+	receiptTimeoutFlag = &cli.DurationFlag{
+		Name:  "receipt-timeout",
+		Usage: "How long to wait for the transaction to be confirmed, 0 to wait forever",
+		Value: 10 * time.Minute,
+	}
+)
+
 var commonWriteFlags = []cli.Flag{
 	&cli.StringFlag{
 		Name:     "contract-address",
@@ -23,8 +55,7 @@ var commonWriteFlags = []cli.Flag{
 	&cli.StringFlag{
 		Name:    "abi-path",
 		Aliases: []string{"b"},
-		Usage:   "Path to the MarketDealWrapper contract ABI file",
-		Value:   "./contracts/out/MarketDealWrapper.sol/MarketDealWrapper.json",
+		Usage:   "Path to a forge build output of the MarketDealWrapper contract to use instead of the embedded ABI",
 	},
 	&cli.StringFlag{
 		Name:    "private-key",
@@ -36,6 +67,16 @@ var commonWriteFlags = []cli.Flag{
 		Aliases: []string{"r"},
 		Usage:   "RPC URL for the Ethereum node (overrides .env)",
 	},
+	privateKeyFileFlag,
+	keystoreFlag,
+	accountFlag,
+	passwordFileFlag,
+	maxFeeFlag,
+	maxPriorityFeeFlag,
+	gasLimitMultiplierFlag,
+	legacyTxFlag,
+	confirmationsFlag,
+	receiptTimeoutFlag,
 }
 
 var WriteContractCmd = &cli.Command{
@@ -90,7 +131,8 @@ var WriteContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.AddStorageProviderAction(ctx, client, params)
+				res, err := contract.AddStorageProviderAction(ctx, client, params)
+				return printTxResult(res, err, "Storage provider added successfully!")
 			},
 		},
 		{
@@ -141,7 +183,8 @@ var WriteContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.UpdateStorageProviderAction(ctx, client, params)
+				res, err := contract.UpdateStorageProviderAction(ctx, client, params)
+				return printTxResult(res, err, "Storage provider updated successfully!")
 			},
 		},
 		{
@@ -157,11 +200,11 @@ var WriteContractCmd = &cli.Command{
 				// Retrieve the address from arguments
 				actorIdStr := c.Args().Get(0)
 				if actorIdStr == "" {
-					return fmt.Errorf("missing actor-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing actor-id argument"))
 				}
 				actorId, err := strconv.ParseUint(actorIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid actor-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid actor-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -171,7 +214,8 @@ var WriteContractCmd = &cli.Command{
 				if err != nil {
 					return err
 				}
-				return contract.AddToWhitelistAction(ctx, client, actorId)
+				res, err := contract.AddToWhitelistAction(ctx, client, actorId)
+				return printTxResult(res, err, "Address added to whitelist successfully!")
 			},
 		},
 		{
@@ -186,11 +230,11 @@ var WriteContractCmd = &cli.Command{
 				// Retrieve the address from arguments
 				actorIdStr := c.Args().Get(0)
 				if actorIdStr == "" {
-					return fmt.Errorf("missing actor-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing actor-id argument"))
 				}
 				actorId, err := strconv.ParseUint(actorIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid actor-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid actor-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -200,7 +244,8 @@ var WriteContractCmd = &cli.Command{
 				if err != nil {
 					return err
 				}
-				return contract.RemoveFromWhitelistAction(ctx, client, actorId)
+				res, err := contract.RemoveFromWhitelistAction(ctx, client, actorId)
+				return printTxResult(res, err, "Address removed from whitelist successfully!")
 			},
 		},
 		{
@@ -213,7 +258,7 @@ var WriteContractCmd = &cli.Command{
 				ctx := context.Background()
 				amount := c.Args().Get(0)
 				if amount == "" {
-					return fmt.Errorf("missing amount argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing amount argument"))
 				}
 
 				client, err := eth.NewETHClient(
@@ -224,7 +269,8 @@ var WriteContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.AddFundsAction(ctx, client, amount)
+				res, err := contract.AddFundsAction(ctx, client, amount)
+				return printTxResult(res, err, "Funds added successfully!")
 			},
 		},
 		{
@@ -237,7 +283,7 @@ var WriteContractCmd = &cli.Command{
 				ctx := context.Background()
 				amount := c.Args().Get(0)
 				if amount == "" {
-					return fmt.Errorf("missing amount argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing amount argument"))
 				}
 
 				client, err := eth.NewETHClient(
@@ -248,7 +294,8 @@ var WriteContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.WithdrawFundsAction(ctx, client, amount)
+				res, err := contract.WithdrawFundsAction(ctx, client, amount)
+				return printTxResult(res, err, "Funds withdrawn successfully!")
 			},
 		},
 		{
@@ -266,8 +313,7 @@ var WriteContractCmd = &cli.Command{
 				&cli.StringFlag{
 					Name:    "abi-path",
 					Aliases: []string{"b"},
-					Usage:   "Path to the contract ABI file",
-					Value:   "./contracts/out/MarketDealWrapper.sol/MarketDealWrapper.json",
+					Usage:   "Path to a forge build output of the MarketDealWrapper contract to use instead of the embedded ABI",
 				},
 				&cli.StringFlag{
 					Name:    "private-key",
@@ -279,18 +325,28 @@ var WriteContractCmd = &cli.Command{
 					Aliases: []string{"r"},
 					Usage:   "RPC URL for the Ethereum node (overrides .env)",
 				},
+				privateKeyFileFlag,
+				keystoreFlag,
+				accountFlag,
+				passwordFileFlag,
+				maxFeeFlag,
+				maxPriorityFeeFlag,
+				gasLimitMultiplierFlag,
+				legacyTxFlag,
+				confirmationsFlag,
+				receiptTimeoutFlag,
 			},
 			Action: func(c *cli.Context) error {
 				ctx := context.Background()
 
 				// Retrieve token address and amount from arguments
 				if c.Args().Len() < 2 {
-					return fmt.Errorf("spender and amount arguments are required")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("spender and amount arguments are required"))
 				}
 				spender := c.Args().Get(0)
 				amount := c.Args().Get(1)
 				if amount == "" {
-					return fmt.Errorf("missing amount argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing amount argument"))
 				}
 
 				// Initialize ETH client
@@ -303,7 +359,8 @@ var WriteContractCmd = &cli.Command{
 				}
 
 				// Execute approve action
-				return contract.ApproveERC20Action(ctx, client, spender, amount)
+				res, err := contract.ApproveERC20Action(ctx, client, spender, amount)
+				return printTxResult(res, err, "ERC20 approval successful!")
 			},
 		},
 		{
@@ -317,13 +374,14 @@ var WriteContractCmd = &cli.Command{
 				tokenAddress := c.Args().Get(0)
 				amount := c.Args().Get(1)
 				if tokenAddress == "" || amount == "" {
-					return fmt.Errorf("missing token or amount argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing token or amount argument"))
 				}
 				client, err := eth.NewETHClient(ctx, c)
 				if err != nil {
 					return err
 				}
-				return contract.AddFundsERC20Action(ctx, client, tokenAddress, amount)
+				res, err := contract.AddFundsERC20Action(ctx, client, tokenAddress, amount)
+				return printTxResult(res, err, "ERC20 funds added successfully!")
 			},
 		},
 		{
@@ -337,13 +395,14 @@ var WriteContractCmd = &cli.Command{
 				tokenAddress := c.Args().Get(0)
 				amount := c.Args().Get(1)
 				if tokenAddress == "" || amount == "" {
-					return fmt.Errorf("missing token or amount argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing token or amount argument"))
 				}
 				client, err := eth.NewETHClient(ctx, c)
 				if err != nil {
 					return err
 				}
-				return contract.WithdrawFundsERC20Action(ctx, client, tokenAddress, amount)
+				res, err := contract.WithdrawFundsERC20Action(ctx, client, tokenAddress, amount)
+				return printTxResult(res, err, "ERC20 funds withdrawn successfully!")
 			},
 		},
 		{
@@ -356,13 +415,14 @@ var WriteContractCmd = &cli.Command{
 				ctx := context.Background()
 				token := c.Args().Get(0)
 				if token == "" {
-					return fmt.Errorf("missing token argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing token argument"))
 				}
 				client, err := eth.NewETHClient(ctx, c)
 				if err != nil {
 					return err
 				}
-				return contract.WithdrawSpFundsByTokenAction(ctx, client, token)
+				res, err := contract.WithdrawSpFundsByTokenAction(ctx, client, token)
+				return printTxResult(res, err, "SP funds withdrawn by token successfully!")
 			},
 		},
 		{
@@ -375,11 +435,11 @@ var WriteContractCmd = &cli.Command{
 				ctx := context.Background()
 				dealIdStr := c.Args().Get(0)
 				if dealIdStr == "" {
-					return fmt.Errorf("missing deal-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing deal-id argument"))
 				}
 				dealId, err := strconv.ParseUint(dealIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid deal-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid deal-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -390,7 +450,8 @@ var WriteContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.WithdrawSpFundsForDealAction(ctx, client, dealId)
+				res, err := contract.WithdrawSpFundsForDealAction(ctx, client, dealId)
+				return printTxResult(res, err, "SP funds withdrawn for deal successfully!")
 			},
 		},
 		{
@@ -403,11 +464,11 @@ var WriteContractCmd = &cli.Command{
 				ctx := context.Background()
 				dealIdStr := c.Args().Get(0)
 				if dealIdStr == "" {
-					return fmt.Errorf("missing deal-id argument")
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("missing deal-id argument"))
 				}
 				dealId, err := strconv.ParseUint(dealIdStr, 10, 64)
 				if err != nil {
-					return fmt.Errorf("invalid deal-id: %v", err)
+					return types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid deal-id: %v", err))
 				}
 
 				client, err := eth.NewETHClient(
@@ -418,8 +479,25 @@ var WriteContractCmd = &cli.Command{
 					return err
 				}
 
-				return contract.WithdrawSpFundsForTerminatedDealAction(ctx, client, dealId)
+				res, err := contract.WithdrawSpFundsForTerminatedDealAction(ctx, client, dealId)
+				return printTxResult(res, err, "SP funds withdrawn for terminated deal successfully!")
 			},
 		},
 	},
 }
+
+// printTxResult prints the outcome of a contract transaction, success being
+// the message of a successful one in text mode
+func printTxResult(res *types.TxResult, err error, success string) error {
+	return printResult(res, err, func(res *types.TxResult) {
+		switch {
+		case res.Status == types.TxStatusSuccess:
+			fmt.Println(success)
+		case res.RevertReason != "":
+			fmt.Printf("Transaction failed: %s\n", res.RevertReason)
+		default:
+			fmt.Println("Transaction failed.")
+		}
+		fmt.Printf("block: %d, gas used: %d\n", res.BlockNumber, res.GasUsed)
+	})
+}
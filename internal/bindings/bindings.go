@@ -0,0 +1,26 @@
+// Package bindings holds the Go bindings of the contracts in ./contracts,
+// generated by abigen from their ABI.
+package bindings
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// go generate rebuilds the contract, extracts its ABI and generates the
+// bindings of market_deal_wrapper.go from it.
+//go:generate sh -c "cd ../../contracts && forge build && jq .abi out/MarketDealWrapper.sol/MarketDealWrapper.json > ../internal/bindings/MarketDealWrapper.abi.json"
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi MarketDealWrapper.abi.json --pkg bindings --type MarketDealWrapper --out market_deal_wrapper.go
+
+// NewMarketDealWrapperWithABI is NewMarketDealWrapper for a MarketDealWrapper
+// ABI loaded from elsewhere, such as a contract built from modified sources,
+// rather than the one the bindings were generated from.
+func NewMarketDealWrapperWithABI(address common.Address, parsed abi.ABI, backend bind.ContractBackend) *MarketDealWrapper {
+	contract := bind.NewBoundContract(address, parsed, backend, backend, backend)
+	return &MarketDealWrapper{
+		MarketDealWrapperCaller:     MarketDealWrapperCaller{contract: contract},
+		MarketDealWrapperTransactor: MarketDealWrapperTransactor{contract: contract},
+		MarketDealWrapperFilterer:   MarketDealWrapperFilterer{contract: contract},
+	}
+}
@@ -0,0 +1,77 @@
+package bindings
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Names of the RevertError of a require or revert with a reason string and of
+// a failed assertion or arithmetic check
+const (
+	RevertReasonErrorName = "Error"
+	PanicErrorName        = "Panic"
+)
+
+// RevertError is the revert data of a call decoded against the errors of a
+// contract ABI and the Error(string) and Panic(uint256) builtins.
+type RevertError struct {
+	// Name is the name of the error, empty when it is not in the ABI
+	Name string
+	// Args are the arguments of the error, the reason string for Error and
+	// the meaning of the code for Panic
+	Args []interface{}
+	// Data is the raw revert data
+	Data []byte
+
+	inputs abi.Arguments
+}
+
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == RevertReasonErrorName || e.Name == PanicErrorName:
+		return fmt.Sprintf("execution reverted: %s", e.Args[0])
+	case e.Name != "":
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprintf("%s: %v", e.inputs[i].Name, arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+	case len(e.Data) == 0:
+		return "execution reverted"
+	default:
+		return fmt.Sprintf("execution reverted with unknown error 0x%x", e.Data)
+	}
+}
+
+// UnpackRevert decodes the revert data of a call to a contract of
+// contractABI. Data matching no known error is kept raw in a RevertError
+// without a Name.
+func UnpackRevert(contractABI abi.ABI, data []byte) *RevertError {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		name := RevertReasonErrorName
+		if !bytes.HasPrefix(data, revertSelector) {
+			name = PanicErrorName
+		}
+		return &RevertError{Name: name, Args: []interface{}{reason}, Data: data}
+	}
+	if len(data) >= 4 {
+		for _, abiError := range contractABI.Errors {
+			if !bytes.Equal(data[:4], abiError.ID[:4]) {
+				continue
+			}
+			args, err := abiError.Inputs.Unpack(data[4:])
+			if err != nil {
+				break
+			}
+			return &RevertError{Name: abiError.Name, Args: args, Data: data, inputs: abiError.Inputs}
+		}
+	}
+	return &RevertError{Data: data}
+}
+
+// revertSelector is the selector of Error(string)
+var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
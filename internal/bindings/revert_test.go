@@ -0,0 +1,91 @@
+package bindings
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// encodeRevert encodes the revert data of the error with signature sig and
+// the arguments args
+func encodeRevert(t *testing.T, sig string, args abi.Arguments, values ...interface{}) []byte {
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(crypto.Keccak256([]byte(sig))[:4], packed...)
+}
+
+func newArguments(t *testing.T, types ...string) abi.Arguments {
+	var args abi.Arguments
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	return args
+}
+
+func TestUnpackRevert(t *testing.T) {
+	contractABI, err := MarketDealWrapperMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		errName  string
+		expected string
+	}{
+		{
+			name:     "reason string",
+			data:     encodeRevert(t, "Error(string)", newArguments(t, "string"), "not whitelisted"),
+			errName:  RevertReasonErrorName,
+			expected: "execution reverted: not whitelisted",
+		},
+		{
+			name:     "panic",
+			data:     encodeRevert(t, "Panic(uint256)", newArguments(t, "uint256"), big.NewInt(0x11)),
+			errName:  PanicErrorName,
+			expected: "execution reverted: arithmetic underflow or overflow",
+		},
+		{
+			name:     "error without arguments",
+			data:     encodeRevert(t, "InsufficientBalance()", nil),
+			errName:  "InsufficientBalance",
+			expected: "execution reverted: InsufficientBalance()",
+		},
+		{
+			name:     "error with arguments",
+			data:     encodeRevert(t, "OwnableUnauthorizedAccount(address)", newArguments(t, "address"), account),
+			errName:  "OwnableUnauthorizedAccount",
+			expected: "execution reverted: OwnableUnauthorizedAccount(account: " + account.Hex() + ")",
+		},
+		{
+			name:     "unknown selector",
+			data:     []byte{0xde, 0xad, 0xbe, 0xef},
+			expected: "execution reverted with unknown error 0xdeadbeef",
+		},
+		{
+			name:     "no data",
+			expected: "execution reverted",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reverted := UnpackRevert(*contractABI, tc.data)
+			if reverted.Name != tc.errName {
+				t.Errorf("got error name %q, expected %q", reverted.Name, tc.errName)
+			}
+			if got := reverted.Error(); got != tc.expected {
+				t.Errorf("got %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
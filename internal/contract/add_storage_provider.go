@@ -6,12 +6,10 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
-	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // StorageProviderParams holds parameters for updating a storage provider
@@ -23,7 +21,7 @@ type StorageProviderParams struct {
 }
 
 // AddStorageProviderAction performs the CLI action to add a storage provider
-func AddStorageProviderAction(ctx context.Context, client *types.ETHClient, params StorageProviderParams) error {
+func AddStorageProviderAction(ctx context.Context, client *types.ETHClient, params StorageProviderParams) (*types.TxResult, error) {
 
 	// Prepare transaction input
 	input, err := client.ContractABI.Pack("addStorageProvider",
@@ -33,22 +31,21 @@ func AddStorageProviderAction(ctx context.Context, client *types.ETHClient, para
 		params.PricePerBytePerEpoch,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
+		return nil, fmt.Errorf("failed to pack parameters: %v", err)
 	}
 
 	// Estimate gas limit
-	gasLimit, err := utils.EstimateGas(client.Client, client.FromAddress, client.ContractAddr, input)
+	gasLimit, err := eth.EstimateGasLimit(client, input)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create transaction options
 	txOpts := types.TransactionOptions{
 		FromAddress:     client.FromAddress,
 		PrivateKey:      client.PrivateKey,
-		GasPrice:        client.GasPrice,
+		Fees:            client.Fees,
 		GasLimit:        gasLimit,
-		Nonce:           client.Nonce,
 		ChainID:         client.ChainID,
 		ContractAddress: client.ContractAddr,
 		ABI:             client.ContractABI,
@@ -58,25 +55,13 @@ func AddStorageProviderAction(ctx context.Context, client *types.ETHClient, para
 	}
 
 	// Sign and send transaction
-	signedTx, err := eth.SignAndSendTransaction(ctx, client.Client, txOpts, input)
+	signedTx, err := client.Txs.Send(ctx, txOpts, input)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fmt.Printf("Transaction sent: %s\n", signedTx.Hash().Hex())
 	fmt.Println("Waiting for confirmation...")
 
-	// Wait for receipt
-	receipt, err := eth.WaitForReceipt(ctx, client.Client, signedTx.Hash())
-	if err != nil {
-		return fmt.Errorf("failed to get transaction receipt: %v", err)
-	}
-
-	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
-		fmt.Println("Storage provider added successfully!")
-	} else {
-		fmt.Println("Transaction failed.")
-	}
-
-	return nil
+	return client.Txs.Wait(ctx, signedTx)
 }
@@ -7,23 +7,21 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
-	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // ApproveERC20Action approves the MarketDealWrapper contract to spend a specified amount of ERC20 tokens.
-func ApproveERC20Action(ctx context.Context, client *types.ETHClient, spender string, amount string) error {
+func ApproveERC20Action(ctx context.Context, client *types.ETHClient, spender string, amount string) (*types.TxResult, error) {
 
 	// amount is in wei, convert
 	weiAmount := new(big.Int)
 	_, ok := weiAmount.SetString(amount, 10)
 	if !ok {
-		return fmt.Errorf("invalid amount: %s", amount)
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid amount: %s", amount))
 	}
 	fmt.Printf("Adding ERC20 funds: %s Wei\n", weiAmount.String())
 
@@ -35,28 +33,27 @@ func ApproveERC20Action(ctx context.Context, client *types.ETHClient, spender st
 
 	parsedABI, err := abi.JSON(strings.NewReader(erc20ABI))
 	if err != nil {
-		return fmt.Errorf("failed to parse ERC20 ABI: %v", err)
+		return nil, fmt.Errorf("failed to parse ERC20 ABI: %v", err)
 	}
 
 	// Prepare transaction input by encoding the approve method and parameters
 	input, err := parsedABI.Pack("approve", spenderAddr, weiAmount)
 	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
+		return nil, fmt.Errorf("failed to pack parameters: %v", err)
 	}
 
 	// Estimate gas limit
-	gasLimit, err := utils.EstimateGas(client.Client, client.FromAddress, client.ContractAddr, input)
+	gasLimit, err := eth.EstimateGasLimit(client, input)
 	if err != nil {
-		return fmt.Errorf("gas estimation failed: %v", err)
+		return nil, err
 	}
 
 	// Create transaction options
 	txOpts := types.TransactionOptions{
 		FromAddress:     client.FromAddress,
 		PrivateKey:      client.PrivateKey,
-		GasPrice:        client.GasPrice,
+		Fees:            client.Fees,
 		GasLimit:        gasLimit,
-		Nonce:           client.Nonce,
 		ChainID:         client.ChainID,
 		ContractAddress: client.ContractAddr,
 		ABI:             parsedABI,
@@ -66,25 +63,13 @@ func ApproveERC20Action(ctx context.Context, client *types.ETHClient, spender st
 	}
 
 	// Sign and send transaction
-	signedTx, err := eth.SignAndSendTransaction(ctx, client.Client, txOpts, input)
+	signedTx, err := client.Txs.Send(ctx, txOpts, input)
 	if err != nil {
-		return fmt.Errorf("failed to approve ERC20 tokens: %v", err)
+		return nil, fmt.Errorf("failed to approve ERC20 tokens: %w", err)
 	}
 
 	fmt.Printf("ERC20 approval transaction sent. Transaction hash: %s\n", signedTx.Hash().Hex())
 	fmt.Println("Waiting for confirmation...")
 
-	// Wait for receipt
-	receipt, err := eth.WaitForReceipt(ctx, client.Client, signedTx.Hash())
-	if err != nil {
-		return fmt.Errorf("failed to get transaction receipt: %v", err)
-	}
-
-	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
-		fmt.Println("ERC20 approval successful!")
-	} else {
-		return fmt.Errorf("transaction failed with status: %v", receipt.Status)
-	}
-
-	return nil
+	return client.Txs.Wait(ctx, signedTx)
 }
@@ -6,35 +6,22 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 
-	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
-// GetDealsFromMinerIdAction retrieves deal IDs associated with a given miner ID
-func GetDealsFromMinerIdAction(ctx context.Context, client *types.ETHClient, minerId uint64) error {
-	// Prepare call input
-	input, err := client.ContractABI.Pack("getDealsFromMinerId", minerId)
-	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
-	}
-
-	// Make the call
-	callMsg := ethereum.CallMsg{
-		To:   &client.ContractAddr,
-		Data: input,
-	}
-
-	output, err := client.Client.CallContract(ctx, callMsg, nil)
-	if err != nil {
-		return fmt.Errorf("failed to call contract: %v", err)
-	}
+// MinerDeals are the deals of a storage provider known to the contract
+type MinerDeals struct {
+	MinerId uint64   `json:"minerId"`
+	DealIds []uint64 `json:"dealIds"`
+}
 
-	// Unpack the result into a slice of uint64
-	var dealIds []uint64
-	err = client.ContractABI.UnpackIntoInterface(&dealIds, "getDealsFromMinerId", output)
+// GetDealsFromMinerIdAction retrieves deal IDs associated with a given miner ID
+func GetDealsFromMinerIdAction(ctx context.Context, client *types.ETHClient, minerId uint64) (*MinerDeals, error) {
+	// Call the contract
+	dealIds, err := client.Contract.GetDealsFromMinerId(&bind.CallOpts{Context: ctx}, minerId)
 	if err != nil {
-		return fmt.Errorf("failed to unpack result: %v", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to call contract: %v", err))
 	}
 
-	fmt.Printf("Deal IDs for Miner ID %d: %v\n", minerId, dealIds)
-	return nil
+	return &MinerDeals{MinerId: minerId, DealIds: dealIds}, nil
 }
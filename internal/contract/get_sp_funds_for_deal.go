@@ -8,35 +8,22 @@ import (
 
 	"math/big"
 
-	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
-// GetSpFundsForDealAction retrieves the currently claimable SP funds for a specific deal
-func GetSpFundsForDealAction(ctx context.Context, client *types.ETHClient, dealId uint64) error {
-	// Prepare call input
-	input, err := client.ContractABI.Pack("getSpFundsForDeal", dealId)
-	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
-	}
-
-	// Make the call
-	callMsg := ethereum.CallMsg{
-		To:   &client.ContractAddr,
-		Data: input,
-	}
-
-	output, err := client.Client.CallContract(ctx, callMsg, nil)
-	if err != nil {
-		return fmt.Errorf("failed to call contract: %v", err)
-	}
+// DealFunds are the funds a storage provider can claim for a deal
+type DealFunds struct {
+	DealId uint64   `json:"dealId"`
+	Funds  *big.Int `json:"funds"`
+}
 
-	// Unpack the result into a uint256
-	var funds *big.Int
-	err = client.ContractABI.UnpackIntoInterface(&funds, "getSpFundsForDeal", output)
+// GetSpFundsForDealAction retrieves the currently claimable SP funds for a specific deal
+func GetSpFundsForDealAction(ctx context.Context, client *types.ETHClient, dealId uint64) (*DealFunds, error) {
+	// Call the contract
+	funds, err := client.Contract.GetSpFundsForDeal(&bind.CallOpts{Context: ctx}, dealId)
 	if err != nil {
-		return fmt.Errorf("failed to unpack result: %v", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to call contract: %v", err))
 	}
 
-	fmt.Printf("Currently claimable SP funds for Deal ID %d: %s\n", dealId, funds.String())
-	return nil
+	return &DealFunds{DealId: dealId, Funds: funds}, nil
 }
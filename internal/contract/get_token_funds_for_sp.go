@@ -8,39 +8,27 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 
-	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// TokenFunds are the funds of a token a storage provider can claim
+type TokenFunds struct {
+	Token   common.Address `json:"token"`
+	ActorId uint64         `json:"actorId"`
+	Funds   *big.Int       `json:"funds"`
+}
+
 // GetTokenFundsForSPAction retrieves the currently claimable SP funds for a specific ERC20 token and actor ID
-func GetTokenFundsForSPAction(ctx context.Context, client *types.ETHClient, tokenAddress string, actorId uint64) error {
+func GetTokenFundsForSPAction(ctx context.Context, client *types.ETHClient, tokenAddress string, actorId uint64) (*TokenFunds, error) {
 	// Convert string token address to common.Address
 	token := common.HexToAddress(tokenAddress)
 
-	// Prepare call input
-	input, err := client.ContractABI.Pack("getTokenFundsForSp", token, actorId)
-	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
-	}
-
-	// Make the call
-	callMsg := ethereum.CallMsg{
-		To:   &client.ContractAddr,
-		Data: input,
-	}
-
-	output, err := client.Client.CallContract(ctx, callMsg, nil)
-	if err != nil {
-		return fmt.Errorf("failed to call contract: %v", err)
-	}
-
-	// Unpack the result into a uint256
-	var funds *big.Int
-	err = client.ContractABI.UnpackIntoInterface(&funds, "getTokenFundsForSp", output)
+	// Call the contract
+	funds, err := client.Contract.GetTokenFundsForSp(&bind.CallOpts{Context: ctx}, token, actorId)
 	if err != nil {
-		return fmt.Errorf("failed to unpack result: %v", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to call contract: %v", err))
 	}
 
-	fmt.Printf("Currently claimable SP funds for Token %s and Actor ID %d: %s\n", token.Hex(), actorId, funds.String())
-	return nil
+	return &TokenFunds{Token: token, ActorId: actorId, Funds: funds}, nil
 }
@@ -6,36 +6,23 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 
-	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
-// IsWhitelistedAction checks if a given address is whitelisted
-func IsWhitelistedAction(ctx context.Context, client *types.ETHClient, actorId uint64) error {
-
-	// Prepare call input
-	input, err := client.ContractABI.Pack("isWhitelisted", actorId)
-	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
-	}
-
-	// Make the call
-	callMsg := ethereum.CallMsg{
-		To:   &client.ContractAddr,
-		Data: input,
-	}
+// WhitelistStatus tells whether an actor may sign deals for the contract
+type WhitelistStatus struct {
+	ActorId       uint64 `json:"actorId"`
+	IsWhitelisted bool   `json:"isWhitelisted"`
+}
 
-	output, err := client.Client.CallContract(ctx, callMsg, nil)
-	if err != nil {
-		return fmt.Errorf("failed to call contract: %v", err)
-	}
+// IsWhitelistedAction checks if a given address is whitelisted
+func IsWhitelistedAction(ctx context.Context, client *types.ETHClient, actorId uint64) (*WhitelistStatus, error) {
 
-	// Unpack the result into a boolean
-	var isWhitelisted bool
-	err = client.ContractABI.UnpackIntoInterface(&isWhitelisted, "isWhitelisted", output)
+	// Call the contract
+	isWhitelisted, err := client.Contract.IsWhitelisted(&bind.CallOpts{Context: ctx}, actorId)
 	if err != nil {
-		return fmt.Errorf("failed to unpack result: %v", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to call contract: %v", err))
 	}
 
-	fmt.Printf("Is actor-id %d whitelisted? %t\n", actorId, isWhitelisted)
-	return nil
+	return &WhitelistStatus{ActorId: actorId, IsWhitelisted: isWhitelisted}, nil
 }
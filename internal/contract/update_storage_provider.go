@@ -6,21 +6,19 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
-	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // UpdateStorageProviderAction performs the CLI action to update a storage provider
-func UpdateStorageProviderAction(ctx context.Context, client *types.ETHClient, params StorageProviderParams) error {
+func UpdateStorageProviderAction(ctx context.Context, client *types.ETHClient, params StorageProviderParams) (*types.TxResult, error) {
 	// If EthAddr or Token is not provided, fetch existing storage provider details
 	if params.EthAddr == (common.Address{}) || params.Token == (common.Address{}) {
 		spDetails, err := GetSpFromIdAction(ctx, client,  params.ActorId)
 		if err != nil {
-			return fmt.Errorf("failed to fetch existing storage provider details: %v", err)
+			return nil, fmt.Errorf("failed to fetch existing storage provider details: %v", err)
 		}
 		if params.EthAddr == (common.Address{}) {
 			params.EthAddr = spDetails.EthAddr
@@ -41,22 +39,21 @@ func UpdateStorageProviderAction(ctx context.Context, client *types.ETHClient, p
 		params.PricePerBytePerEpoch,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
+		return nil, fmt.Errorf("failed to pack parameters: %v", err)
 	}
 
 	// Estimate gas limit
-	gasLimit, err := utils.EstimateGas(client.Client, client.FromAddress, client.ContractAddr, input)
+	gasLimit, err := eth.EstimateGasLimit(client, input)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create transaction options
 	txOpts := types.TransactionOptions{
 		FromAddress:     client.FromAddress,
 		PrivateKey:      client.PrivateKey,
-		GasPrice:        client.GasPrice,
+		Fees:            client.Fees,
 		GasLimit:        gasLimit,
-		Nonce:           client.Nonce,
 		ChainID:         client.ChainID,
 		ContractAddress: client.ContractAddr,
 		ABI:             client.ContractABI,
@@ -66,25 +63,13 @@ func UpdateStorageProviderAction(ctx context.Context, client *types.ETHClient, p
 	}
 
 	// Sign and send transaction
-	signedTx, err := eth.SignAndSendTransaction(ctx, client.Client, txOpts, input)
+	signedTx, err := client.Txs.Send(ctx, txOpts, input)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fmt.Printf("Transaction sent: %s\n", signedTx.Hash().Hex())
 	fmt.Println("Waiting for confirmation...")
 
-	// Wait for receipt
-	receipt, err := eth.WaitForReceipt(ctx, client.Client, signedTx.Hash())
-	if err != nil {
-		return fmt.Errorf("failed to get transaction receipt: %v", err)
-	}
-
-	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
-		fmt.Println("Storage provider updated successfully!")
-	} else {
-		fmt.Println("Transaction failed.")
-	}
-
-	return nil
+	return client.Txs.Wait(ctx, signedTx)
 }
@@ -7,19 +7,17 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/eth"
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
-	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"github.com/ethereum/go-ethereum/common"
-	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // WithdrawFundsERC20Action withdraws ERC20 tokens from the MarketDealWrapper contract
-func WithdrawFundsERC20Action(ctx context.Context, client *types.ETHClient, tokenAddress string, amount string) error {
+func WithdrawFundsERC20Action(ctx context.Context, client *types.ETHClient, tokenAddress string, amount string) (*types.TxResult, error) {
 	// amount is in wei, convert
 	weiAmount := new(big.Int)
 	_, ok := weiAmount.SetString(amount, 10)
 	if !ok {
-		return fmt.Errorf("invalid amount: %s", amount)
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid amount: %s", amount))
 	}
 	fmt.Printf("Withdrawing ERC20 funds: %s Wei\n", weiAmount.String())
 
@@ -29,22 +27,21 @@ func WithdrawFundsERC20Action(ctx context.Context, client *types.ETHClient, toke
 	// Prepare transaction input by encoding the method and parameters
 	input, err := client.ContractABI.Pack("withdrawFundsERC20", token, weiAmount)
 	if err != nil {
-		return fmt.Errorf("failed to pack parameters: %v", err)
+		return nil, fmt.Errorf("failed to pack parameters: %v", err)
 	}
 
 	// Estimate gas limit
-	gasLimit, err := utils.EstimateGas(client.Client, client.FromAddress, client.ContractAddr, input)
+	gasLimit, err := eth.EstimateGasLimit(client, input)
 	if err != nil {
-		return fmt.Errorf("gas estimation failed: %v", err)
+		return nil, err
 	}
 
 	// Create transaction options
 	txOpts := types.TransactionOptions{
 		FromAddress:     client.FromAddress,
 		PrivateKey:      client.PrivateKey,
-		GasPrice:        client.GasPrice,
+		Fees:            client.Fees,
 		GasLimit:        gasLimit,
-		Nonce:           client.Nonce,
 		ChainID:         client.ChainID,
 		ContractAddress: client.ContractAddr,
 		ABI:             client.ContractABI,
@@ -54,25 +51,13 @@ func WithdrawFundsERC20Action(ctx context.Context, client *types.ETHClient, toke
 	}
 
 	// Sign and send transaction
-	signedTx, err := eth.SignAndSendTransaction(ctx, client.Client, txOpts, input)
+	signedTx, err := client.Txs.Send(ctx, txOpts, input)
 	if err != nil {
-		return fmt.Errorf("failed to withdraw ERC20 funds: %v", err)
+		return nil, fmt.Errorf("failed to withdraw ERC20 funds: %w", err)
 	}
 
 	fmt.Printf("ERC20 funds withdrawn. Transaction hash: %s\n", signedTx.Hash().Hex())
 	fmt.Println("Waiting for confirmation...")
 
-	// Wait for receipt
-	receipt, err := eth.WaitForReceipt(ctx, client.Client, signedTx.Hash())
-	if err != nil {
-		return fmt.Errorf("failed to get transaction receipt: %v", err)
-	}
-
-	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
-		fmt.Println("ERC20 funds withdrawn successfully!")
-	} else {
-		fmt.Println("Transaction failed.")
-	}
-
-	return nil
+	return client.Txs.Wait(ctx, signedTx)
 }
@@ -12,8 +12,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/eastore-project/fil-deal-wrapper/internal/bindings"
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
-	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,7 +28,6 @@ func NewETHClient(ctx context.Context, c *cli.Context) (*types.ETHClient, error)
 
 	// Parse flags
 	rpcURL := c.String("rpc-url")
-	privateKeyHex := c.String("private-key")
 	contractAddress := c.String("contract-address")
 	abiPath := c.String("abi-path")
 
@@ -42,32 +41,18 @@ func NewETHClient(ctx context.Context, c *cli.Context) (*types.ETHClient, error)
 	if rpcURL == "" {
 		rpcURL = os.Getenv("RPC_URL")
 		if rpcURL == "" {
-			return nil, fmt.Errorf("RPC URL must be provided via flag or .env")
-		}
-	}
-
-	// Use private key from flag or environment
-	if privateKeyHex == "" {
-		privateKeyHex = os.Getenv("ETH_PRIVATE_KEY")
-		if privateKeyHex == "" {
-			return nil, fmt.Errorf("private key must be provided via flag or .env")
+			return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("RPC URL must be provided via flag or .env"))
 		}
 	}
 
 	// Connect to Ethereum node
 	client, err := ethclient.Dial(rpcURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum node: %v", err)
-	}
-
-	// Load and parse ABI
-	contractABI, err := loadABI(abiPath)
-	if err != nil {
-		return nil, err
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to connect to Ethereum node: %v", err))
 	}
 
-	// Load private key
-	privateKey, fromAddress, err := loadPrivateKey(privateKeyHex)
+	// Load the signing key
+	privateKey, fromAddress, err := LoadSigner(c)
 	if err != nil {
 		return nil, err
 	}
@@ -75,30 +60,52 @@ func NewETHClient(ctx context.Context, c *cli.Context) (*types.ETHClient, error)
 	// Parse contract address
 	contractAddr := common.HexToAddress(contractAddress)
 
-	// Get nonce
-	nonce := utils.GetNonce(client, fromAddress)
+	// Bind the contract with the ABI the bindings were generated from unless
+	// one is given
+	var contract *bindings.MarketDealWrapper
+	var contractABI abi.ABI
+	if abiPath != "" {
+		contractABI, err = loadABI(abiPath)
+		if err != nil {
+			return nil, types.WithCode(types.ErrCodeInvalidInput, err)
+		}
+		contract = bindings.NewMarketDealWrapperWithABI(contractAddr, contractABI, client)
+	} else {
+		parsed, err := bindings.MarketDealWrapperMetaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		contractABI = *parsed
+		contract, err = bindings.NewMarketDealWrapper(contractAddr, client)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	gasPrice, err := client.SuggestGasPrice(ctx)
+	fees, err := SuggestFees(ctx, c, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to suggest gas price: %v", err)
+		return nil, err
 	}
 
 	// Get chain ID
 	chainID, err := client.NetworkID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get network ID: %v", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get network ID: %v", err))
 	}
 
-	return &types.ETHClient{
-		Client:       client,
-		PrivateKey:   privateKey,
-		FromAddress:  fromAddress,
-		ContractABI:  contractABI,
-		ContractAddr: contractAddr,
-		ChainID:      chainID,
-		Nonce:        nonce,
-		GasPrice:     gasPrice,
-	}, nil
+	ethClient := &types.ETHClient{
+		Client:             client,
+		PrivateKey:         privateKey,
+		FromAddress:        fromAddress,
+		Contract:           contract,
+		ContractABI:        contractABI,
+		ContractAddr:       contractAddr,
+		ChainID:            chainID,
+		Fees:               *fees,
+		GasLimitMultiplier: c.Float64("gas-limit-multiplier"),
+	}
+	ethClient.Txs = NewTxManager(ethClient, c.Duration("receipt-timeout"), c.Uint64("confirmations"))
+	return ethClient, nil
 }
 
 // loadABI loads and parses the ABI from the given path
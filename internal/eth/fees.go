@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// baseFeeMultiplier is how many times the current base fee the default fee
+// cap covers, so that a transaction stays valid while the base fee rises
+const baseFeeMultiplier = 2
+
+// parseFeeFlag parses the attoFIL amount of flag name, nil when it is unset
+func parseFeeFlag(c *cli.Context, name string) (*big.Int, error) {
+	if !c.IsSet(name) {
+		return nil, nil
+	}
+	fee, ok := new(big.Int).SetString(c.String(name), 10)
+	if !ok || fee.Sign() < 0 {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid --%s: %s", name, c.String(name)))
+	}
+	return fee, nil
+}
+
+// SuggestFees works out the gas pricing from the --legacy-tx, --max-fee and
+// --max-priority-fee flags and the node. EIP-1559 transactions tip the
+// --max-priority-fee, or what the node suggests, and pay at most --max-fee
+// per gas, by default twice the base fee plus the tip. Legacy transactions
+// pay the gas price the node suggests, capped at --max-fee.
+func SuggestFees(ctx context.Context, c *cli.Context, client *ethclient.Client) (*types.TxFees, error) {
+	maxFee, err := parseFeeFlag(c, "max-fee")
+	if err != nil {
+		return nil, err
+	}
+	maxPriorityFee, err := parseFeeFlag(c, "max-priority-fee")
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Bool("legacy-tx") {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to suggest gas price: %v", err))
+		}
+		if maxFee != nil && gasPrice.Cmp(maxFee) > 0 {
+			gasPrice = maxFee
+		}
+		return &types.TxFees{Legacy: true, GasPrice: gasPrice}, nil
+	}
+
+	tipCap := maxPriorityFee
+	if tipCap == nil {
+		tipCap, err = client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to suggest gas tip cap: %v", err))
+		}
+		// a suggested tip never goes over the cap
+		if maxFee != nil && tipCap.Cmp(maxFee) > 0 {
+			tipCap = maxFee
+		}
+	}
+
+	feeCap := maxFee
+	if feeCap == nil {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get chain head: %v", err))
+		}
+		if head.BaseFee == nil {
+			return nil, fmt.Errorf("chain does not support EIP-1559 transactions, use --legacy-tx")
+		}
+		feeCap = new(big.Int).Mul(head.BaseFee, big.NewInt(baseFeeMultiplier))
+		feeCap.Add(feeCap, tipCap)
+	}
+	if feeCap.Cmp(tipCap) < 0 {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("max fee %s is lower than max priority fee %s", feeCap, tipCap))
+	}
+	return &types.TxFees{GasTipCap: tipCap, GasFeeCap: feeCap}, nil
+}
@@ -0,0 +1,170 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// DefaultKeystoreDir is the keystore of eth key and of --account when
+// --keystore is not given
+const DefaultKeystoreDir = "~/.wrappedeal/keystore"
+
+// KeystorePasswordEnv is the environment variable read for the keystore
+// password when no --password-file is given
+const KeystorePasswordEnv = "ETH_KEYSTORE_PASSWORD"
+
+// LoadSigner loads the key signing transactions from, in order, --private-key,
+// --private-key-file, the --account of --keystore when either is given, and
+// the ETH_PRIVATE_KEY environment variable.
+func LoadSigner(c *cli.Context) (*ecdsa.PrivateKey, common.Address, error) {
+	switch {
+	case c.String("private-key") != "":
+		return loadPrivateKeyWithCode(c.String("private-key"))
+	case c.String("private-key-file") != "":
+		return LoadPrivateKeyFile(c.String("private-key-file"))
+	case c.IsSet("keystore") || c.IsSet("account"):
+		ks, err := OpenKeystore(c.String("keystore"))
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		account, err := FindAccount(ks, c.String("account"))
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		password, err := ReadPassword(c.String("password-file"), fmt.Sprintf("Password of %s: ", account.Address.Hex()), false)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		return DecryptAccount(account, password)
+	case os.Getenv("ETH_PRIVATE_KEY") != "":
+		return loadPrivateKeyWithCode(os.Getenv("ETH_PRIVATE_KEY"))
+	}
+	return nil, common.Address{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("private key must be provided via --private-key, --private-key-file, --keystore/--account or ETH_PRIVATE_KEY in .env"))
+}
+
+// loadPrivateKeyWithCode is loadPrivateKey with its errors tagged invalid input
+func loadPrivateKeyWithCode(privateKeyHex string) (*ecdsa.PrivateKey, common.Address, error) {
+	privateKey, fromAddress, err := loadPrivateKey(privateKeyHex)
+	if err != nil {
+		return nil, common.Address{}, types.WithCode(types.ErrCodeInvalidInput, err)
+	}
+	return privateKey, fromAddress, nil
+}
+
+// LoadPrivateKeyFile loads the hex private key held by the file path
+func LoadPrivateKeyFile(path string) (*ecdsa.PrivateKey, common.Address, error) {
+	path, err := utils.ExpandPath(path)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, common.Address{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("failed to read private key file: %v", err))
+	}
+	privateKey, fromAddress, err := loadPrivateKey(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, common.Address{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("%s: %v", path, err))
+	}
+	return privateKey, fromAddress, nil
+}
+
+// OpenKeystore opens the encrypted keystore in the directory dir, the default
+// one when dir is empty
+func OpenKeystore(dir string) (*keystore.KeyStore, error) {
+	if dir == "" {
+		dir = DefaultKeystoreDir
+	}
+	dir, err := utils.ExpandPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP), nil
+}
+
+// FindAccount returns the account of ks with the given address, or its only
+// account when address is empty
+func FindAccount(ks *keystore.KeyStore, address string) (accounts.Account, error) {
+	if address == "" {
+		accs := ks.Accounts()
+		if len(accs) != 1 {
+			return accounts.Account{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("keystore holds %d accounts, pick one with --account", len(accs)))
+		}
+		return accs[0], nil
+	}
+	if !common.IsHexAddress(address) {
+		return accounts.Account{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid account address %q", address))
+	}
+	account, err := ks.Find(accounts.Account{Address: common.HexToAddress(address)})
+	if err != nil {
+		return accounts.Account{}, types.WithCode(types.ErrCodeNotFound, fmt.Errorf("account %s: %v", address, err))
+	}
+	return account, nil
+}
+
+// DecryptAccount decrypts the key file of account with password
+func DecryptAccount(account accounts.Account, password string) (*ecdsa.PrivateKey, common.Address, error) {
+	keyJSON, err := os.ReadFile(account.URL.Path)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to read key file: %v", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, common.Address{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("failed to decrypt key of %s: %v", account.Address.Hex(), err))
+	}
+	return key.PrivateKey, key.Address, nil
+}
+
+// ReadPassword reads a keystore password from passwordFile, else from the
+// ETH_KEYSTORE_PASSWORD environment variable, else by prompting for it on the
+// terminal, twice when confirm is set.
+func ReadPassword(passwordFile string, prompt string, confirm bool) (string, error) {
+	if passwordFile != "" {
+		path, err := utils.ExpandPath(passwordFile)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("failed to read password file: %v", err))
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if password, ok := os.LookupEnv(KeystorePasswordEnv); ok {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("no keystore password, use --password-file or %s", KeystorePasswordEnv))
+	}
+	// prompts go to stderr, stdout may be piped
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %v", err)
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat password: ")
+		repeated, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %v", err)
+		}
+		if string(repeated) != string(password) {
+			return "", types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("passwords do not match"))
+		}
+	}
+	return string(password), nil
+}
@@ -0,0 +1,30 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/bindings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// revertError decodes the revert data the node attached to err, the error of
+// a call or gas estimation, against the errors of contractABI. It returns
+// nil when err carries no revert data.
+func revertError(contractABI abi.ABI, err error) *bindings.RevertError {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil || len(data) == 0 {
+		return nil
+	}
+	return bindings.UnpackRevert(contractABI, data)
+}
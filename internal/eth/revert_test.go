@@ -0,0 +1,81 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/bindings"
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// newRevertingNode serves a JSON-RPC node whose eth_estimateGas reverts with
+// data, attached to the error as nodes do
+func newRevertingNode(t *testing.T, data []byte) *ethclient.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Method != "eth_estimateGas" {
+			t.Errorf("unexpected call of %s", req.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error": map[string]interface{}{
+				"code":    3,
+				"message": "execution reverted",
+				"data":    hexutil.Encode(data),
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	client, err := ethclient.Dial(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+func TestEstimateGasLimitReverted(t *testing.T) {
+	contractABI, err := bindings.MarketDealWrapperMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := crypto.Keccak256([]byte("InsufficientBalance()"))[:4]
+	client := &types.ETHClient{Client: newRevertingNode(t, data), ContractABI: *contractABI}
+
+	_, err = EstimateGasLimit(client, []byte{0x01})
+	var reverted *bindings.RevertError
+	if !errors.As(err, &reverted) {
+		t.Fatalf("expected a revert error, got %v", err)
+	}
+	if reverted.Name != "InsufficientBalance" {
+		t.Fatalf("got error %q, expected InsufficientBalance", reverted.Name)
+	}
+	if err.Error() != "failed to estimate gas: execution reverted: InsufficientBalance()" {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+// TestRevertErrorWithoutData checks that errors without revert data, such as
+// network errors, are left as they are
+func TestRevertErrorWithoutData(t *testing.T) {
+	if reverted := revertError(abi.ABI{}, errors.New("connection refused")); reverted != nil {
+		t.Fatal("expected no revert error")
+	}
+}
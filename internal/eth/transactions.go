@@ -3,46 +3,70 @@ package eth
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
-	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// SignAndSendTransaction signs and sends the transaction
+// EstimateGasLimit estimates the gas of calling the contract of client with
+// input, scaled by the gas limit multiplier of client. When the call reverts
+// the error tells why.
+func EstimateGasLimit(client *types.ETHClient, input []byte) (uint64, error) {
+	gasLimit, err := utils.EstimateGas(client.Client, client.FromAddress, client.ContractAddr, input)
+	if err != nil {
+		if reverted := revertError(client.ContractABI, err); reverted != nil {
+			return 0, fmt.Errorf("failed to estimate gas: %w", reverted)
+		}
+		return 0, err
+	}
+	if client.GasLimitMultiplier > 0 {
+		gasLimit = uint64(float64(gasLimit) * client.GasLimitMultiplier)
+	}
+	return gasLimit, nil
+}
+
+// SignAndSendTransaction signs and sends the transaction, an EIP-1559
+// dynamic fee transaction unless opts.Fees is legacy
 func SignAndSendTransaction(ctx context.Context, client *ethclient.Client, opts types.TransactionOptions, input []byte) (*ethTypes.Transaction, error) {
-	tx := ethTypes.NewTransaction(
-		opts.Nonce,
-		opts.ContractAddress,
-		opts.Value,
-		opts.GasLimit,
-		opts.GasPrice,
-		input,
-	)
-
-	signedTx, err := ethTypes.SignTx(tx, ethTypes.NewEIP155Signer(opts.ChainID), opts.PrivateKey)
+	var tx *ethTypes.Transaction
+	var signer ethTypes.Signer
+	if opts.Fees.Legacy {
+		tx = ethTypes.NewTransaction(
+			opts.Nonce,
+			opts.ContractAddress,
+			opts.Value,
+			opts.GasLimit,
+			opts.Fees.GasPrice,
+			input,
+		)
+		signer = ethTypes.NewEIP155Signer(opts.ChainID)
+	} else {
+		to := opts.ContractAddress
+		tx = ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+			ChainID:   opts.ChainID,
+			Nonce:     opts.Nonce,
+			GasTipCap: opts.Fees.GasTipCap,
+			GasFeeCap: opts.Fees.GasFeeCap,
+			Gas:       opts.GasLimit,
+			To:        &to,
+			Value:     opts.Value,
+			Data:      input,
+		})
+		signer = ethTypes.NewLondonSigner(opts.ChainID)
+	}
+
+	signedTx, err := ethTypes.SignTx(tx, signer, opts.PrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %v", err)
 	}
 
 	err = client.SendTransaction(ctx, signedTx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %v", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to send transaction: %v", err))
 	}
 
 	return signedTx, nil
 }
-
-// WaitForReceipt waits until the transaction receipt is available
-func WaitForReceipt(ctx context.Context, client *ethclient.Client, txHash common.Hash) (*ethTypes.Receipt, error) {
-	for {
-		receipt, err := client.TransactionReceipt(ctx, txHash)
-		if err == nil {
-			return receipt, nil
-		}
-		time.Sleep(1 * time.Second)
-	}
-}
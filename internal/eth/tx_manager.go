@@ -0,0 +1,300 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// receiptPollInterval is how often Wait looks for the receipt
+	receiptPollInterval = 5 * time.Second
+	// droppedAfterPolls is how many polls in a row a transaction must be
+	// missing from both the chain and the mempool to be reported dropped
+	droppedAfterPolls = 3
+	// replacementFeeBump is the percentage of the fees of a pending
+	// transaction a replacement must pay for the lotus mempool to take it
+	replacementFeeBump = 125
+)
+
+// TxManager sends the transactions of the account of an ETHClient. It hands
+// out consecutive nonces, so that a sequence of transactions can be sent
+// without waiting for each one to be mined, waits for receipts with a
+// timeout and a confirmation depth, notices transactions dropped from the
+// mempool, and replaces pending transactions to speed them up or cancel them.
+type TxManager struct {
+	client *types.ETHClient
+	// ReceiptTimeout bounds how long Wait waits, no bound when 0
+	ReceiptTimeout time.Duration
+	// Confirmations is the number of blocks, from the one including the
+	// transaction, Wait waits for
+	Confirmations uint64
+
+	mu sync.Mutex
+	// nonce follows the last transaction sent when haveNonce is set
+	nonce     uint64
+	haveNonce bool
+}
+
+// NewTxManager returns a TxManager sending the transactions of client
+func NewTxManager(client *types.ETHClient, receiptTimeout time.Duration, confirmations uint64) *TxManager {
+	return &TxManager{
+		client:         client,
+		ReceiptTimeout: receiptTimeout,
+		Confirmations:  confirmations,
+	}
+}
+
+// Send gives opts the next nonce of the account, then signs and sends the
+// transaction.
+func (m *TxManager) Send(ctx context.Context, opts types.TransactionOptions, input []byte) (*ethTypes.Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonce, err := m.client.Client.PendingNonceAt(ctx, m.client.FromAddress)
+	if err != nil {
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get nonce: %v", err))
+	}
+	// the node may not have seen the transactions sent just before
+	if m.haveNonce && m.nonce > nonce {
+		nonce = m.nonce
+	}
+	opts.Nonce = nonce
+
+	tx, err := SignAndSendTransaction(ctx, m.client.Client, opts, input)
+	if err != nil {
+		return nil, err
+	}
+	m.nonce = nonce + 1
+	m.haveNonce = true
+	return tx, nil
+}
+
+// Wait waits until tx is mined and has Confirmations blocks, and sums up its
+// receipt. A reverted transaction is returned along with an ErrCodeTxFailed
+// error. It gives up with an ErrCodeTxDropped error when tx leaves the
+// mempool without being mined, and with an ErrCodeTxTimeout error after
+// ReceiptTimeout.
+func (m *TxManager) Wait(ctx context.Context, tx *ethTypes.Transaction) (*types.TxResult, error) {
+	parent := ctx
+	if m.ReceiptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.ReceiptTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	missing := 0
+	for {
+		receipt, err := m.client.Client.TransactionReceipt(ctx, tx.Hash())
+		switch {
+		case err == nil:
+			missing = 0
+			confirmed, err := m.confirmed(ctx, receipt)
+			if err != nil && ctx.Err() == nil {
+				return nil, err
+			}
+			if confirmed {
+				return m.txResult(ctx, tx, receipt)
+			}
+		case errors.Is(err, ethereum.NotFound):
+			known, err := m.known(ctx, tx.Hash())
+			if err != nil && ctx.Err() == nil {
+				return nil, err
+			}
+			if known {
+				missing = 0
+			} else if missing++; missing >= droppedAfterPolls {
+				return nil, m.droppedError(ctx, tx)
+			}
+		case ctx.Err() == nil:
+			return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get receipt of transaction %s: %v", tx.Hash().Hex(), err))
+		}
+
+		select {
+		case <-ctx.Done():
+			if parent.Err() == nil {
+				return nil, types.WithCode(types.ErrCodeTxTimeout, fmt.Errorf("transaction %s not confirmed after %s, speed it up with tx speedup", tx.Hash().Hex(), m.ReceiptTimeout))
+			}
+			return nil, parent.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// confirmed tells whether receipt has Confirmations blocks
+func (m *TxManager) confirmed(ctx context.Context, receipt *ethTypes.Receipt) (bool, error) {
+	if m.Confirmations <= 1 {
+		return true, nil
+	}
+	head, err := m.client.Client.BlockNumber(ctx)
+	if err != nil {
+		return false, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get block number: %v", err))
+	}
+	return head+1 >= receipt.BlockNumber.Uint64()+m.Confirmations, nil
+}
+
+// known tells whether the node knows of the transaction txHash, pending or mined
+func (m *TxManager) known(ctx context.Context, txHash common.Hash) (bool, error) {
+	_, _, err := m.client.Client.TransactionByHash(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get transaction %s: %v", txHash.Hex(), err))
+	}
+	return true, nil
+}
+
+// droppedError tells whether tx was replaced or dropped from the mempool
+func (m *TxManager) droppedError(ctx context.Context, tx *ethTypes.Transaction) error {
+	nonce, err := m.client.Client.NonceAt(ctx, m.client.FromAddress, nil)
+	if err == nil && nonce > tx.Nonce() {
+		return types.WithCode(types.ErrCodeTxDropped, fmt.Errorf("transaction %s was replaced by another transaction with nonce %d", tx.Hash().Hex(), tx.Nonce()))
+	}
+	return types.WithCode(types.ErrCodeTxDropped, fmt.Errorf("transaction %s was dropped from the mempool", tx.Hash().Hex()))
+}
+
+// txResult sums up the receipt of tx, with the reason it reverted when it failed
+func (m *TxManager) txResult(ctx context.Context, tx *ethTypes.Transaction, receipt *ethTypes.Receipt) (*types.TxResult, error) {
+	res := &types.TxResult{
+		TxHash:      tx.Hash(),
+		Status:      types.TxStatusSuccess,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+	}
+	if receipt.Status == ethTypes.ReceiptStatusSuccessful {
+		return res, nil
+	}
+	res.Status = types.TxStatusFailed
+	res.RevertReason = m.revertReason(ctx, tx, receipt.BlockNumber)
+	if res.RevertReason == "" {
+		return res, types.WithCode(types.ErrCodeTxFailed, fmt.Errorf("transaction %s failed", tx.Hash().Hex()))
+	}
+	return res, types.WithCode(types.ErrCodeTxFailed, fmt.Errorf("transaction %s failed: %s", tx.Hash().Hex(), res.RevertReason))
+}
+
+// revertReason replays tx with eth_call at block, the block it was mined in,
+// to find out why it reverted. Receipts do not hold the revert data. It
+// returns an empty string when the replay does not fail.
+func (m *TxManager) revertReason(ctx context.Context, tx *ethTypes.Transaction, block *big.Int) string {
+	msg := ethereum.CallMsg{
+		From:  m.client.FromAddress,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	_, err := m.client.Client.CallContract(ctx, msg, block)
+	if err == nil {
+		return ""
+	}
+	if reverted := revertError(m.client.ContractABI, err); reverted != nil {
+		return reverted.Error()
+	}
+	return err.Error()
+}
+
+// SpeedUp replaces the pending transaction txHash with the same transaction
+// paying higher fees.
+func (m *TxManager) SpeedUp(ctx context.Context, txHash common.Hash) (*ethTypes.Transaction, error) {
+	tx, err := m.pendingTx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.To() == nil {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("transaction %s creates a contract, it cannot be sped up", txHash.Hex()))
+	}
+	return m.replace(ctx, tx, *tx.To(), tx.Value(), tx.Gas(), tx.Data())
+}
+
+// Cancel replaces the pending transaction txHash with a transfer of nothing
+// to the account itself paying higher fees.
+func (m *TxManager) Cancel(ctx context.Context, txHash common.Hash) (*ethTypes.Transaction, error) {
+	tx, err := m.pendingTx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, err := utils.EstimateGas(m.client.Client, m.client.FromAddress, m.client.FromAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if m.client.GasLimitMultiplier > 0 {
+		gasLimit = uint64(float64(gasLimit) * m.client.GasLimitMultiplier)
+	}
+	return m.replace(ctx, tx, m.client.FromAddress, big.NewInt(0), gasLimit, nil)
+}
+
+// pendingTx returns the transaction txHash, which must be pending and sent
+// from the account
+func (m *TxManager) pendingTx(ctx context.Context, txHash common.Hash) (*ethTypes.Transaction, error) {
+	tx, isPending, err := m.client.Client.TransactionByHash(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, types.WithCode(types.ErrCodeNotFound, fmt.Errorf("transaction %s not found", txHash.Hex()))
+	}
+	if err != nil {
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to get transaction %s: %v", txHash.Hex(), err))
+	}
+	if !isPending {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("transaction %s is already mined", txHash.Hex()))
+	}
+	sender, err := ethTypes.Sender(ethTypes.LatestSignerForChainID(m.client.ChainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender of transaction %s: %v", txHash.Hex(), err)
+	}
+	if sender != m.client.FromAddress {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("transaction %s was sent by %s, not %s", txHash.Hex(), sender.Hex(), m.client.FromAddress.Hex()))
+	}
+	return tx, nil
+}
+
+// replace sends a transaction at the nonce of old paying enough more than
+// old for the mempool to replace it
+func (m *TxManager) replace(ctx context.Context, old *ethTypes.Transaction, to common.Address, value *big.Int, gasLimit uint64, input []byte) (*ethTypes.Transaction, error) {
+	opts := types.TransactionOptions{
+		FromAddress:     m.client.FromAddress,
+		PrivateKey:      m.client.PrivateKey,
+		Fees:            replacementFees(old, m.client.Fees),
+		GasLimit:        gasLimit,
+		Nonce:           old.Nonce(),
+		ChainID:         m.client.ChainID,
+		ContractAddress: to,
+		Value:           value,
+	}
+	return SignAndSendTransaction(ctx, m.client.Client, opts, input)
+}
+
+// replacementFees raises fees to at least replacementFeeBump percent of the
+// fees of old
+func replacementFees(old *ethTypes.Transaction, fees types.TxFees) types.TxFees {
+	bump := func(fee *big.Int, oldFee *big.Int) *big.Int {
+		min := new(big.Int).Mul(oldFee, big.NewInt(replacementFeeBump))
+		min.Add(min, big.NewInt(99))
+		min.Div(min, big.NewInt(100))
+		if fee == nil || fee.Cmp(min) < 0 {
+			return min
+		}
+		return fee
+	}
+	if fees.Legacy {
+		fees.GasPrice = bump(fees.GasPrice, old.GasPrice())
+		return fees
+	}
+	fees.GasTipCap = bump(fees.GasTipCap, old.GasTipCap())
+	fees.GasFeeCap = bump(fees.GasFeeCap, old.GasFeeCap())
+	if fees.GasFeeCap.Cmp(fees.GasTipCap) < 0 {
+		fees.GasFeeCap = fees.GasTipCap
+	}
+	return fees
+}
@@ -5,13 +5,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
+	wtypes "github.com/eastore-project/fil-deal-wrapper/internal/types"
 	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"github.com/ethereum/go-ethereum/common"
 	clinode "github.com/filecoin-project/boost/cli/node"
 	"github.com/filecoin-project/boost/cmd"
+	"github.com/filecoin-project/boost/lib/keystore"
 	"github.com/filecoin-project/boost/storagemarket/types"
 	types2 "github.com/filecoin-project/boost/transport/types"
 	"github.com/filecoin-project/go-address"
@@ -22,34 +27,78 @@ import (
 	"github.com/filecoin-project/go-state-types/builtin/v9/market"
 	"github.com/filecoin-project/lotus/api"
 	chain_types "github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/wallet"
 	lcli "github.com/filecoin-project/lotus/cli"
 	"github.com/google/uuid"
 	"github.com/ipfs/go-cid"
 	inet "github.com/libp2p/go-libp2p/core/network"
+	"github.com/mitchellh/go-homedir"
 	"github.com/urfave/cli/v2"
 )
 
 const DealProtocolv120 = "/fil/storage/mk/1.2.0"
 
-func DealCmdAction(cctx *cli.Context, isOnline bool) error {
+func DealCmdAction(cctx *cli.Context, isOnline bool) (*DealSummary, error) {
 	ctx := context.Background()
 	api, closer, err := lcli.GetGatewayAPI(cctx)
 	if err != nil {
-		return fmt.Errorf("cant setup gateway connection: %w", err)
+		return nil, wtypes.WithCode(wtypes.ErrCodeRPC, fmt.Errorf("cant setup gateway connection: %w", err))
 	}
 	defer closer()
 
-	err = MakeDeal(
+	commp := cctx.String("commp")
+	payloadCid := cctx.String("payload-cid")
+	pieceSize := cctx.Uint64("piece-size")
+	carSize := cctx.Uint64("car-size")
+	verifyURLCommp := cctx.Bool("verify-url-commp")
+	if isOnline && cctx.Bool("from-url") {
+		headers, err := parseHTTPHeaders(cctx.StringSlice("http-headers"))
+		if err != nil {
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, err)
+		}
+		fmt.Println("downloading car to derive the deal parameters", "url", cctx.String("http-url"))
+		remote, err := utils.InspectCarURL(ctx, cctx.String("http-url"), headers, pieceSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect car url: %w", err)
+		}
+		if commp != "" && commp != remote.PieceCid {
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("--commp %s does not match the commp of the car at the url %s", commp, remote.PieceCid))
+		}
+		if payloadCid != "" && payloadCid != remote.PayloadCid {
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("--payload-cid %s does not match the root of the car at the url %s", payloadCid, remote.PayloadCid))
+		}
+		if carSize != 0 && carSize != remote.CarSize {
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("--car-size %d does not match the size of the car at the url %d", carSize, remote.CarSize))
+		}
+		fmt.Println("derived deal parameters", "commp", remote.PieceCid, "piece size", remote.PieceSize,
+			"payload cid", remote.PayloadCid, "car size", remote.CarSize)
+		commp, payloadCid, pieceSize, carSize = remote.PieceCid, remote.PayloadCid, remote.PieceSize, remote.CarSize
+		// the commp has just been computed from the url
+		verifyURLCommp = false
+	} else {
+		required := []string{"commp", "piece-size", "payload-cid"}
+		if isOnline {
+			required = append(required, "car-size")
+		}
+		for _, name := range required {
+			if !cctx.IsSet(name) {
+				return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("required flag %q not set", name))
+			}
+		}
+	}
+
+	summary, err := MakeDeal(
 		ctx,
 		api,
 		cctx.String("repo"),
 		cctx.String("wallet"),
-		cctx.String("commp"),
-		cctx.String("payload-cid"),
+		commp,
+		payloadCid,
 		cctx.String("http-url"),
-		cctx.String("provider"),
-		cctx.Uint64("piece-size"),
-		cctx.Uint64("car-size"),
+		cctx.StringSlice("provider"),
+		cctx.Int("replicas"),
+		pieceSize,
+		carSize,
 		cctx.Int("start-epoch-head-offset"),
 		cctx.Int("start-epoch"),
 		cctx.Int("duration"),
@@ -60,15 +109,84 @@ func DealCmdAction(cctx *cli.Context, isOnline bool) error {
 		cctx.Bool("skip-ipni-announce"),
 		isOnline,
 		cctx.StringSlice("http-headers"),
+		nil,
+		cctx.Bool("skip-url-check"),
+		verifyURLCommp,
+		cctx.Bool("skip-preflight"),
+		cctx.Bool("dry-run"),
+		cctx.String("dry-run-dir"),
 		cctx.String("contract"),
 	)
 	if err != nil {
-		return fmt.Errorf("deal failed: %w", err)
+		return summary, fmt.Errorf("deal failed: %w", err)
 	}
 
-	return nil
+	return summary, nil
+}
+
+// DealResult is the outcome of proposing a deal to one storage provider
+type DealResult struct {
+	Provider string    `json:"provider"`
+	DealUuid uuid.UUID `json:"dealUuid"`
+	Accepted bool      `json:"accepted"`
+	// Message is the rejection reason or the error that stopped the proposal
+	Message string `json:"message,omitempty"`
+	// Params and ProposalFile are the signed parameters and the file their
+	// proposal was written to by a dry run
+	Params       *types.DealParams `json:"params,omitempty"`
+	ProposalFile string            `json:"proposalFile,omitempty"`
 }
 
+// DealSummary is the outcome of MakeDeal
+type DealSummary struct {
+	// Client is the f4 address of the wrapper contract
+	Client     string `json:"client"`
+	Signer     string `json:"signer"`
+	PayloadCid string `json:"payloadCid"`
+	// URL is empty for offline deals
+	URL                string          `json:"url,omitempty"`
+	PieceCid           string          `json:"pieceCid"`
+	PieceSize          uint64          `json:"pieceSize"`
+	Offline            bool            `json:"offline"`
+	StartEpoch         abi.ChainEpoch  `json:"startEpoch"`
+	EndEpoch           abi.ChainEpoch  `json:"endEpoch"`
+	ProviderCollateral abi.TokenAmount `json:"providerCollateral"`
+	// DryRun is set when the proposals were signed but not sent
+	DryRun bool         `json:"dryRun"`
+	Deals  []DealResult `json:"deals"`
+}
+
+// setupClientNode sets up the boost client node of repo. A dry run only
+// signs proposals, so it gets the wallet of the repo alone, without the
+// libp2p host clinode.Setup starts.
+func setupClientNode(repo string, dryRun bool) (*clinode.Node, error) {
+	if !dryRun {
+		return clinode.Setup(repo)
+	}
+	repoDir, err := homedir.Expand(repo)
+	if err != nil {
+		return nil, fmt.Errorf("getting homedir: %w", err)
+	}
+	if _, err := os.Stat(repoDir); errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New("repo dir doesn't exist. run `boost init` first.")
+	}
+	kstore, err := keystore.OpenOrInitKeystore(filepath.Join(repoDir, "wallet"))
+	if err != nil {
+		return nil, err
+	}
+	w, err := wallet.NewWallet(kstore)
+	if err != nil {
+		return nil, err
+	}
+	return &clinode.Node{Wallet: w}, nil
+}
+
+// MakeDeal proposes the same piece to every provider in providers using a
+// single boost client node, sending the proposals concurrently. When
+// replicas is non-zero providers is a pool of candidates: proposals go out
+// to replicas providers at a time, replacing the ones that reject the deal
+// with the next candidates, until replicas deals are accepted or the pool is
+// exhausted.
 func MakeDeal(
 	ctx context.Context,
 	api api.Gateway,
@@ -77,7 +195,8 @@ func MakeDeal(
 	commp string,
 	payloadCidStr string,
 	url string,
-	provider string,
+	providers []string,
+	replicas int,
 	pieceSize uint64,
 	carSize uint64,
 	startEpochHeadOffset int,
@@ -90,92 +209,90 @@ func MakeDeal(
 	skipIPNIAnnounce bool,
 	isOnline bool,
 	httpHeaders []string,
+	providerHeaders map[string][]string,
+	skipURLCheck bool,
+	verifyURLCommp bool,
+	skipPreflight bool,
+	dryRun bool,
+	dryRunDir string,
 	contract string,
-) error {
-	n, err := clinode.Setup(repo)
-	if err != nil {
-		return err
+) (*DealSummary, error) {
+	if len(providers) == 0 {
+		return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("no storage provider given"))
 	}
-
-	walletAddr, err := n.GetProvidedOrDefaultWallet(ctx, wallet)
-	if err != nil {
-		return err
+	seen := make(map[string]bool)
+	for _, provider := range providers {
+		if _, err := address.NewFromString(provider); err != nil {
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("invalid storage provider address %s: %w", provider, err))
+		}
+		if seen[provider] {
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("storage provider %s is given more than once", provider))
+		}
+		seen[provider] = true
+	}
+	if replicas == 0 {
+		replicas = len(providers)
+	}
+	if replicas < 0 || replicas > len(providers) {
+		return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("cannot make %d replicas with %d storage providers", replicas, len(providers)))
 	}
 
-	fmt.Println("selected ", "wallet", walletAddr)
-
-	maddr, err := address.NewFromString(provider)
+	n, err := setupClientNode(repo, dryRun)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	addrInfo, err := cmd.GetAddrInfo(ctx, api, maddr)
+	walletAddr, err := n.GetProvidedOrDefaultWallet(ctx, wallet)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println("found storage provider", "id", addrInfo.ID, "multiaddrs", addrInfo.Addrs, "addr", maddr)
-
-	if err := n.Host.Connect(ctx, *addrInfo); err != nil {
-		return fmt.Errorf("failed to connect to peer %s: %w", addrInfo.ID, err)
-	}
+	fmt.Println("selected ", "wallet", walletAddr)
 
-	x, err := n.Host.Peerstore().FirstSupportedProtocol(addrInfo.ID, DealProtocolv120)
+	store, err := OpenDealStore(repo)
 	if err != nil {
-		return fmt.Errorf("getting protocols for peer %s: %w", addrInfo.ID, err)
-	}
-
-	if len(x) == 0 {
-		return fmt.Errorf("boost client cannot make a deal with storage provider %s because it does not support protocol version 1.2.0", maddr)
+		return nil, err
 	}
-
-	dealUuid := uuid.New()
+	defer store.Close()
 
 	pieceCid, err := cid.Parse(commp)
 	if err != nil {
-		return fmt.Errorf("parsing commp '%s': %w", commp, err)
+		return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("parsing commp '%s': %w", commp, err))
 	}
 
 	rootCid, err := cid.Parse(payloadCidStr)
 	if err != nil {
-		return fmt.Errorf("parsing payload cid %s: %w", payloadCidStr, err)
+		return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("parsing payload cid %s: %w", payloadCidStr, err))
 	}
-	transfer := types.Transfer{}
 	if isOnline {
 		if carSize == 0 {
-			return fmt.Errorf("size of car file cannot be 0")
+			return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("size of car file cannot be 0"))
 		}
 
-		transfer.Size = carSize
-		// Store the path to the CAR file as a transfer parameter
-		transferParams := &types2.HttpRequest{URL: url}
-
-		if url != "" {
-			transferParams.Headers = make(map[string]string)
-
-			for _, header := range httpHeaders {
-				sp := strings.Split(header, "=")
-				if len(sp) != 2 {
-					return fmt.Errorf("malformed http header: %s", header)
+		// fail now rather than after the SP has accepted the deal and
+		// wasted a transfer slot on a dead link or a wrong size
+		if !skipURLCheck {
+			headers, err := parseHTTPHeaders(httpHeaders, providerHeaders[providers[0]])
+			if err != nil {
+				return nil, err
+			}
+			if err := utils.CheckCarURL(ctx, url, headers, carSize); err != nil {
+				return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("car url check failed: %w", err))
+			}
+			if verifyURLCommp {
+				fmt.Println("downloading car to verify its commp", "url", url)
+				if err := utils.CheckCarURLCommp(ctx, url, headers, carSize, commp, pieceSize); err != nil {
+					return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("car url check failed: %w", err))
 				}
-
-				transferParams.Headers[sp[0]] = sp[1]
 			}
 		}
-
-		paramsBytes, err := json.Marshal(transferParams)
-		if err != nil {
-			return fmt.Errorf("marshalling request parameters: %w", err)
-		}
-		transfer.Type = "http"
-		transfer.Params = paramsBytes
 	}
 
 	var providerCollateralAmount abi.TokenAmount
 	if providerCollateral == 0 {
 		bounds, err := api.StateDealProviderCollateralBounds(ctx, abi.PaddedPieceSize(pieceSize), verified, chain_types.EmptyTSK)
 		if err != nil {
-			return fmt.Errorf("node error getting collateral bounds: %w", err)
+			return nil, wtypes.WithCode(wtypes.ErrCodeRPC, fmt.Errorf("node error getting collateral bounds: %w", err))
 		}
 
 		providerCollateralAmount = big.Div(big.Mul(bounds.Min, big.NewInt(6)), big.NewInt(5)) // add 20%
@@ -185,13 +302,13 @@ func MakeDeal(
 
 	tipset, err := api.ChainHead(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot get chain head: %w", err)
+		return nil, wtypes.WithCode(wtypes.ErrCodeRPC, fmt.Errorf("cannot get chain head: %w", err))
 	}
 
 	head := tipset.Height()
 
 	if startEpochHeadOffset > 0 && startEpoch > 0 {
-		return errors.New("only one flag from `start-epoch-head-offset' or `start-epoch` can be specified")
+		return nil, wtypes.WithCode(wtypes.ErrCodeInvalidInput, errors.New("only one flag from `start-epoch-head-offset' or `start-epoch` can be specified"))
 	}
 
 	var effectiveStartEpoch abi.ChainEpoch
@@ -206,80 +323,293 @@ func MakeDeal(
 	ethAddr := common.HexToAddress(contract)
 	filClient, err := address.NewDelegatedAddress(builtin.EthereumAddressManagerActorID, ethAddr[:])
 	if err != nil {
-		return fmt.Errorf("failed to translate onramp address (%s) into a "+
+		return nil, fmt.Errorf("failed to translate onramp address (%s) into a "+
 			"Filecoin f4 address: %w", ethAddr, err)
 	}
 
 	// put the signer's actorId in label for signer verification in contract
 	signerActorId, err := api.StateLookupID(ctx, walletAddr, chain_types.EmptyTSK)
 	if err != nil {
-		return fmt.Errorf("failed to lookup actorId for signer address: %w", err)
+		return nil, wtypes.WithCode(wtypes.ErrCodeRPC, fmt.Errorf("failed to lookup actorId for signer address: %w", err))
 	}
 	stringActorId, err := utils.GetStringActorId(signerActorId)
 	if err != nil {
-		return fmt.Errorf("failed to convert actorId to string: %w", err)
+		return nil, fmt.Errorf("failed to convert actorId to string: %w", err)
 	}
 
 	label, err := market.NewLabelFromString(stringActorId)
 	if err != nil {
-		return fmt.Errorf("failed to create label: %w", err)
+		return nil, fmt.Errorf("failed to create label: %w", err)
 	}
 
-	// Create a deal proposal to storage provider using deal protocol v1.2.0 format
-	dealProposal, err := DealProposal(
-		ctx, n, filClient, walletAddr, abi.PaddedPieceSize(pieceSize), pieceCid, maddr, label, effectiveStartEpoch, duration, verified, providerCollateralAmount, abi.NewTokenAmount(storagePrice),
-	)
+	// fail now rather than when PublishStorageDeals reverts in the contract
+	if !skipPreflight {
+		signerId, err := address.IDFromAddress(signerActorId)
+		if err != nil {
+			return nil, err
+		}
+		maddrs := make([]address.Address, len(providers))
+		for i, provider := range providers {
+			maddrs[i], _ = address.NewFromString(provider)
+		}
+		if err := PreflightDeal(ctx, api, ethAddr, signerId, maddrs, replicas, pieceSize, duration, verified); err != nil {
+			return nil, wtypes.WithCode(wtypes.ErrCodePreflight, fmt.Errorf("pre-flight check failed: %w", err))
+		}
+	}
+
+	// buildParams builds the unsigned parameters of a deal with provider
+	buildParams := func(provider string, dealUuid uuid.UUID) (types.DealParams, error) {
+		transfer := types.Transfer{}
+		if isOnline {
+			transfer.Size = carSize
+			// Store the path to the CAR file as a transfer parameter
+			transferParams := &types2.HttpRequest{URL: url}
+			if url != "" {
+				headers, err := parseHTTPHeaders(httpHeaders, providerHeaders[provider])
+				if err != nil {
+					return types.DealParams{}, err
+				}
+				transferParams.Headers = headers
+			}
+			paramsBytes, err := json.Marshal(transferParams)
+			if err != nil {
+				return types.DealParams{}, fmt.Errorf("marshalling request parameters: %w", err)
+			}
+			transfer.Type = "http"
+			transfer.Params = paramsBytes
+		}
+
+		return types.DealParams{
+			DealUUID:           dealUuid,
+			DealDataRoot:       rootCid,
+			IsOffline:          !isOnline,
+			Transfer:           transfer,
+			RemoveUnsealedCopy: removeUnsealedCopy,
+			SkipIPNIAnnounce:   skipIPNIAnnounce,
+		}, nil
+	}
+	newProposal := func(maddr address.Address) (*market.ClientDealProposal, error) {
+		// Create a deal proposal to storage provider using deal protocol v1.2.0 format
+		return DealProposal(
+			ctx, n, filClient, walletAddr, abi.PaddedPieceSize(pieceSize), pieceCid, maddr, label, effectiveStartEpoch, duration, verified, providerCollateralAmount, abi.NewTokenAmount(storagePrice),
+		)
+	}
+
+	summary := &DealSummary{
+		Client:             filClient.String(),
+		Signer:             walletAddr.String(),
+		PayloadCid:         rootCid.String(),
+		PieceCid:           pieceCid.String(),
+		PieceSize:          pieceSize,
+		Offline:            !isOnline,
+		StartEpoch:         effectiveStartEpoch,
+		EndEpoch:           effectiveStartEpoch + abi.ChainEpoch(duration),
+		ProviderCollateral: providerCollateralAmount,
+		DryRun:             dryRun,
+	}
+	if isOnline {
+		summary.URL = url
+	}
+
+	if dryRun {
+		// sign the proposals without connecting to any provider
+		for _, provider := range providers {
+			params, err := buildParams(provider, uuid.New())
+			if err != nil {
+				return nil, err
+			}
+			maddr, err := address.NewFromString(provider)
+			if err != nil {
+				return nil, err
+			}
+			dealProposal, err := newProposal(maddr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create a deal proposal: %w", err)
+			}
+			params.ClientDealProposal = *dealProposal
+			path, err := dumpDealParams(&params, dryRunDir)
+			if err != nil {
+				return nil, err
+			}
+			summary.Deals = append(summary.Deals, DealResult{
+				Provider:     provider,
+				DealUuid:     params.DealUUID,
+				Params:       &params,
+				ProposalFile: path,
+			})
+		}
+		return summary, nil
+	}
+
+	propose := func(provider string) DealResult {
+		res := DealResult{Provider: provider, DealUuid: uuid.New()}
+
+		dealParams, err := buildParams(provider, res.DealUuid)
+		if err != nil {
+			res.Message = err.Error()
+			return res
+		}
+		accepted, msg, err := proposeDeal(ctx, api, n, provider, &dealParams, newProposal)
+		if err != nil {
+			res.Message = err.Error()
+		} else {
+			res.Accepted = accepted
+			res.Message = msg
+		}
+
+		// record every proposal that was signed, whatever became of it
+		if dealParams.ClientDealProposal.Proposal.PieceCID.Defined() {
+			rec := &DealRecord{
+				DealUuid:   res.DealUuid,
+				Provider:   provider,
+				PieceCid:   pieceCid.String(),
+				PieceSize:  pieceSize,
+				PayloadCid: rootCid.String(),
+				CarSize:    carSize,
+				Client:     filClient.String(),
+				Signer:     walletAddr.String(),
+				Contract:   contract,
+				Params:     dealParams,
+				Status:     DealStatusRejected,
+				Message:    res.Message,
+			}
+			if isOnline {
+				rec.TransferURL = url
+			}
+			if err != nil {
+				rec.Status = DealStatusError
+			} else if res.Accepted {
+				rec.Status = DealStatusAccepted
+			}
+			if err := store.Put(ctx, rec); err != nil {
+				fmt.Println("failed to record deal", "uuid", res.DealUuid, "err", err)
+			}
+		}
+		return res
+	}
+
+	var results []DealResult
+	accepted := 0
+	candidates := providers
+	for accepted < replicas && len(candidates) > 0 {
+		batch := candidates[:min(replicas-accepted, len(candidates))]
+		candidates = candidates[len(batch):]
+
+		batchResults := make([]DealResult, len(batch))
+		var wg sync.WaitGroup
+		for i, provider := range batch {
+			wg.Add(1)
+			go func(i int, provider string) {
+				defer wg.Done()
+				batchResults[i] = propose(provider)
+			}(i, provider)
+		}
+		wg.Wait()
+
+		for _, res := range batchResults {
+			if res.Accepted {
+				accepted++
+			}
+		}
+		results = append(results, batchResults...)
+	}
+
+	summary.Deals = results
+	if accepted < replicas {
+		return summary, wtypes.WithCode(wtypes.ErrCodeDealRejected, fmt.Errorf("%d of %d deal proposals accepted", accepted, replicas))
+	}
+	return summary, nil
+}
+
+// proposeDeal connects to provider, signs the proposal built by newProposal
+// into params and sends them. It returns whether the provider accepted the
+// deal and its message.
+func proposeDeal(
+	ctx context.Context,
+	api api.Gateway,
+	n *clinode.Node,
+	provider string,
+	params *types.DealParams,
+	newProposal func(maddr address.Address) (*market.ClientDealProposal, error),
+) (bool, string, error) {
+	maddr, err := address.NewFromString(provider)
+	if err != nil {
+		return false, "", err
+	}
+
+	addrInfo, err := cmd.GetAddrInfo(ctx, api, maddr)
+	if err != nil {
+		return false, "", err
+	}
+
+	fmt.Println("found storage provider", "id", addrInfo.ID, "multiaddrs", addrInfo.Addrs, "addr", maddr)
+
+	if err := n.Host.Connect(ctx, *addrInfo); err != nil {
+		return false, "", fmt.Errorf("failed to connect to peer %s: %w", addrInfo.ID, err)
+	}
+
+	x, err := n.Host.Peerstore().FirstSupportedProtocol(addrInfo.ID, DealProtocolv120)
 	if err != nil {
-		return fmt.Errorf("failed to create a deal proposal: %w", err)
+		return false, "", fmt.Errorf("getting protocols for peer %s: %w", addrInfo.ID, err)
 	}
 
-	dealParams := types.DealParams{
-		DealUUID:           dealUuid,
-		ClientDealProposal: *dealProposal,
-		DealDataRoot:       rootCid,
-		IsOffline:          !isOnline,
-		Transfer:           transfer,
-		RemoveUnsealedCopy: removeUnsealedCopy,
-		SkipIPNIAnnounce:   skipIPNIAnnounce,
+	if len(x) == 0 {
+		return false, "", fmt.Errorf("boost client cannot make a deal with storage provider %s because it does not support protocol version 1.2.0", maddr)
 	}
 
-	fmt.Println("about to submit deal proposal", "uuid", dealUuid.String())
+	dealProposal, err := newProposal(maddr)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to create a deal proposal: %w", err)
+	}
+	params.ClientDealProposal = *dealProposal
+
+	fmt.Println("about to submit deal proposal", "uuid", params.DealUUID.String(), "provider", maddr)
 
 	s, err := n.Host.NewStream(ctx, addrInfo.ID, DealProtocolv120)
 	if err != nil {
-		return fmt.Errorf("failed to open stream to peer %s: %w", addrInfo.ID, err)
+		return false, "", fmt.Errorf("failed to open stream to peer %s: %w", addrInfo.ID, err)
 	}
 	defer s.Close()
 
 	var resp types.DealResponse
-	if err := doRpc(ctx, s, &dealParams, &resp); err != nil {
-		return fmt.Errorf("send proposal rpc: %w", err)
+	if err := doRpc(ctx, s, params, &resp); err != nil {
+		return false, "", fmt.Errorf("send proposal rpc: %w", err)
 	}
 
-	if !resp.Accepted {
-		return fmt.Errorf("deal proposal rejected: %s", resp.Message)
-	}
+	return resp.Accepted, resp.Message, nil
+}
 
-	msg := "sent deal proposal"
-	if !isOnline {
-		msg += " for offline deal"
+// dumpDealParams writes the CBOR of the signed ClientDealProposal of params
+// to <dir>/<deal uuid>.cbor, returning the file path.
+func dumpDealParams(params *types.DealParams, dir string) (string, error) {
+	buf, err := cborutil.Dump(&params.ClientDealProposal)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode deal proposal: %w", err)
 	}
-	msg += "\n"
-	msg += fmt.Sprintf("  deal uuid: %s\n", dealUuid)
-	msg += fmt.Sprintf("  storage provider: %s\n", maddr)
-	msg += fmt.Sprintf("  client contract address: %s\n", filClient)
-	msg += fmt.Sprintf("  signer wallet: %s\n", walletAddr)
-	msg += fmt.Sprintf("  payload cid: %s\n", rootCid)
-	if isOnline {
-		msg += fmt.Sprintf("  url: %s\n", url)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
 	}
-	msg += fmt.Sprintf("  commp: %s\n", dealProposal.Proposal.PieceCID)
-	msg += fmt.Sprintf("  start epoch: %d\n", dealProposal.Proposal.StartEpoch)
-	msg += fmt.Sprintf("  end epoch: %d\n", dealProposal.Proposal.EndEpoch)
-	msg += fmt.Sprintf("  provider collateral: %s\n", chain_types.FIL(dealProposal.Proposal.ProviderCollateral).Short())
-	fmt.Println(msg)
+	path := filepath.Join(dir, params.DealUUID.String()+".cbor")
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
 
-	return nil
+// parseHTTPHeaders parses lists of key=value headers into a single map
+func parseHTTPHeaders(headerLists ...[]string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, httpHeaders := range headerLists {
+		for _, header := range httpHeaders {
+			sp := strings.Split(header, "=")
+			if len(sp) != 2 {
+				return nil, fmt.Errorf("malformed http header: %s", header)
+			}
+
+			headers[sp[0]] = sp[1]
+		}
+	}
+	return headers, nil
 }
 
 func DealProposal(ctx context.Context, n *clinode.Node, clientAddr address.Address, signerAddr address.Address, pieceSize abi.PaddedPieceSize, pieceCid cid.Cid, minerAddr address.Address, label market.DealLabel, startEpoch abi.ChainEpoch, duration int, verified bool, providerCollateral abi.TokenAmount, storagePrice abi.TokenAmount) (*market.ClientDealProposal, error) {
@@ -0,0 +1,377 @@
+package filecoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	wtypes "github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	clinode "github.com/filecoin-project/boost/cli/node"
+	"github.com/filecoin-project/boost/cmd"
+	"github.com/filecoin-project/boost/storagemarket/types"
+	"github.com/filecoin-project/boost/storagemarket/types/dealcheckpoints"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/lotus/api"
+	lcli "github.com/filecoin-project/lotus/cli"
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/urfave/cli/v2"
+)
+
+const DealStatusProtocolv120 = "/fil/storage/status/1.2.0"
+
+// SignFunc signs msg with the wallet that signed the deal proposal
+type SignFunc func(ctx context.Context, msg []byte) (*crypto.Signature, error)
+
+// GetDealStatus asks the provider at p, over the boost deal status protocol
+// on h, for the state of the deal with dealUuid. The request carries the
+// deal UUID signed with sign.
+func GetDealStatus(ctx context.Context, h host.Host, p peer.ID, dealUuid uuid.UUID, sign SignFunc) (*types.DealStatusResponse, error) {
+	uuidBytes, err := dealUuid.MarshalBinary()
+	if err != nil {
+		return nil, errPermanent{fmt.Errorf("getting uuid bytes: %w", err)}
+	}
+	sig, err := sign(ctx, uuidBytes)
+	if err != nil {
+		return nil, errPermanent{fmt.Errorf("signing uuid bytes: %w", err)}
+	}
+
+	s, err := h.NewStream(ctx, p, DealStatusProtocolv120)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream to peer %s: %w", p, err)
+	}
+	defer s.Close()
+
+	req := types.DealStatusRequest{DealUUID: dealUuid, Signature: *sig}
+	var resp types.DealStatusResponse
+	if err := doRpc(ctx, s, &req, &resp); err != nil {
+		return nil, fmt.Errorf("deal status rpc: %w", err)
+	}
+	if resp.Error != "" {
+		err := fmt.Errorf("provider error: %s", resp.Error)
+		if resp.Error == boostSignatureError {
+			err = fmt.Errorf("%w: %w", errStatusUnverifiable, err)
+		}
+		return nil, errPermanent{err}
+	}
+	if resp.DealStatus == nil {
+		return nil, errPermanent{errors.New("provider returned no deal status")}
+	}
+	return &resp, nil
+}
+
+// boostSignatureError is the error boost answers a deal status request with
+// when its signature does not match the account key of the deal client
+const boostSignatureError = "signature verification failed"
+
+// errStatusUnverifiable is returned when the provider could not verify the
+// signature of a deal status request
+var errStatusUnverifiable = errors.New("the provider cannot verify the deal status request")
+
+// unverifiableStatusError explains why the status of the deal of rec, made
+// for a contract client, cannot be asked from its provider and where to find
+// it instead. cause is the error of the provider when the client is unknown.
+func unverifiableStatusError(dealUuid uuid.UUID, rec *DealRecord, cause error) error {
+	lookup := "run `deals reconcile` to find its on-chain deal ID, then look the deal up with `lotus state get-deal <deal id>` (StateMarketStorageDeal)"
+	if rec != nil && rec.DealID != 0 {
+		lookup = fmt.Sprintf("look it up on chain with `lotus state get-deal %d` (StateMarketStorageDeal)", rec.DealID)
+	}
+	if cause == nil {
+		cause = fmt.Errorf("deal %s was made for the contract %s: boost verifies deal status requests against the key of the deal client, which a contract does not have", dealUuid, rec.Client)
+	} else {
+		cause = fmt.Errorf("%w: boost verifies deal status requests against the key of the deal client, which a contract, as the client of wrapped deals, does not have", cause)
+	}
+	return wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("%w; %s", cause, lookup))
+}
+
+// errPermanent wraps deal status errors that retrying cannot fix, such as
+// the errors answered by the provider
+type errPermanent struct{ error }
+
+func (e errPermanent) Unwrap() error { return e.error }
+
+// dealStatusAttempts is how many times getDealStatusRetrying tries a deal
+// status request that fails on the connection or the stream
+const dealStatusAttempts = 5
+
+// getDealStatusRetrying is GetDealStatus connecting to the provider first,
+// as the connection may have dropped since the last request, and retrying
+// transient failures, waiting backoff and doubling it after every one.
+func getDealStatusRetrying(ctx context.Context, h host.Host, addrInfo peer.AddrInfo, dealUuid uuid.UUID, sign SignFunc, backoff time.Duration) (*types.DealStatusResponse, error) {
+	for attempt := 1; ; attempt++ {
+		var resp *types.DealStatusResponse
+		err := h.Connect(ctx, addrInfo)
+		if err != nil {
+			err = fmt.Errorf("failed to connect to peer %s: %w", addrInfo.ID, err)
+		} else {
+			resp, err = GetDealStatus(ctx, h, addrInfo.ID, dealUuid, sign)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		if errors.As(err, &errPermanent{}) || attempt == dealStatusAttempts {
+			return nil, err
+		}
+		fmt.Println("deal status request failed, retrying", "attempt", attempt, "in", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// DealState sums up a deal status response
+type DealState int
+
+const (
+	// DealInProgress is a deal still being transferred, published or sealed
+	DealInProgress DealState = iota
+	// DealActive is a deal sealed in a proving sector
+	DealActive
+	// DealFailed is a deal that errored, expired or lost its sector
+	DealFailed
+)
+
+// DealStateOf tells whether the deal of resp is active, failed or neither
+func DealStateOf(resp *types.DealStatusResponse) DealState {
+	st := resp.DealStatus
+	if st.Error != "" || st.Status == dealcheckpoints.Complete.String() {
+		return DealFailed
+	}
+	if st.Status != dealcheckpoints.IndexedAndAnnounced.String() {
+		return DealInProgress
+	}
+	switch st.SealingStatus {
+	case "Proving", "Available", "UpdateActivating", "ReleaseSectorKey":
+		return DealActive
+	case "deal not found", "Removing", "Removed", "Terminating", "TerminateWait", "TerminateFinality", "TerminateFailed":
+		// the sector was sealed without the deal, or is going away
+		return DealFailed
+	}
+	return DealInProgress
+}
+
+// DealStatusMessage describes the progress of the deal of resp, following
+// the messages of boost
+func DealStatusMessage(resp *types.DealStatusResponse) string {
+	st := resp.DealStatus
+	switch st.Status {
+	case dealcheckpoints.Accepted.String():
+		if resp.IsOffline {
+			return "Awaiting Offline Data Import"
+		}
+		if resp.NBytesReceived == 0 {
+			return "Transfer Queued"
+		}
+		if resp.NBytesReceived >= resp.TransferSize {
+			return "Transfer Complete"
+		}
+		return fmt.Sprintf("Transferring %.2f%%", 100*float64(resp.NBytesReceived)/float64(resp.TransferSize))
+	case dealcheckpoints.Transferred.String():
+		return "Ready to Publish"
+	case dealcheckpoints.Published.String():
+		return "Awaiting Publish Confirmation"
+	case dealcheckpoints.PublishConfirmed.String():
+		return "Adding to Sector"
+	case dealcheckpoints.AddedPiece.String():
+		return "Announcing"
+	case dealcheckpoints.IndexedAndAnnounced.String():
+		if st.SealingStatus != "" {
+			return "Sealing: " + st.SealingStatus
+		}
+		return "Sealing"
+	case dealcheckpoints.Complete.String():
+		if st.Error != "" {
+			return "Error: " + st.Error
+		}
+		return "Expired"
+	}
+	return st.Status
+}
+
+func (s DealState) String() string {
+	switch s {
+	case DealActive:
+		return "active"
+	case DealFailed:
+		return "failed"
+	}
+	return "in_progress"
+}
+
+// DealStatusResult is the state of a deal as reported by its provider
+type DealStatusResult struct {
+	DealUuid uuid.UUID `json:"dealUuid"`
+	Provider string    `json:"provider"`
+	// Checkpoint is the last boost checkpoint the deal reached
+	Checkpoint string `json:"checkpoint"`
+	// Status is the progress of the deal, see DealStatusMessage
+	Status string `json:"status"`
+	// State is one of in_progress, active and failed
+	State         string `json:"state"`
+	IsOffline     bool   `json:"isOffline"`
+	BytesReceived uint64 `json:"bytesReceived"`
+	TransferSize  uint64 `json:"transferSize"`
+	SealingStatus string `json:"sealingStatus,omitempty"`
+	Error         string `json:"error,omitempty"`
+	PublishCid    string `json:"publishCid,omitempty"`
+	ChainDealID   uint64 `json:"chainDealId,omitempty"`
+}
+
+func newDealStatusResult(provider string, resp *types.DealStatusResponse) *DealStatusResult {
+	st := resp.DealStatus
+	res := &DealStatusResult{
+		DealUuid:      resp.DealUUID,
+		Provider:      provider,
+		Checkpoint:    st.Status,
+		Status:        DealStatusMessage(resp),
+		State:         DealStateOf(resp).String(),
+		IsOffline:     resp.IsOffline,
+		BytesReceived: resp.NBytesReceived,
+		TransferSize:  resp.TransferSize,
+		SealingStatus: st.SealingStatus,
+		Error:         st.Error,
+		ChainDealID:   uint64(st.ChainDealID),
+	}
+	if st.PublishCid != nil {
+		res.PublishCid = st.PublishCid.String()
+	}
+	return res
+}
+
+// DealStatusCmdAction asks the provider of a deal for its status and passes
+// it to report, again every time it changes with --watch. The deal store is
+// only opened to read and update the deal record, so that other commands can
+// use it while the deal is watched.
+//
+// Boost only answers requests signed by the key of the deal client. The
+// client of the deals recorded by this tool is the wrapper contract, which
+// has no key, so their status is not asked for: the error returned points to
+// the on-chain deal instead.
+func DealStatusCmdAction(cctx *cli.Context, report func(*DealStatusResult) error) error {
+	ctx := context.Background()
+
+	dealUuid, err := uuid.Parse(cctx.Args().Get(0))
+	if err != nil {
+		return wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("invalid deal uuid %q: %w", cctx.Args().Get(0), err))
+	}
+
+	// default to the provider and signer of the recorded proposal
+	provider := cctx.String("provider")
+	wallet := cctx.String("wallet")
+	var rec *DealRecord
+	err = withDealStore(cctx.String("repo"), func(store *DealStore) error {
+		rec, err = store.Get(ctx, dealUuid)
+		return err
+	})
+	if err != nil && !errors.Is(err, ErrDealNotFound) {
+		return err
+	}
+	if rec != nil {
+		if provider == "" {
+			provider = rec.Provider
+		}
+		if wallet == "" {
+			wallet = rec.Signer
+		}
+	}
+	if provider == "" {
+		return wtypes.WithCode(wtypes.ErrCodeInvalidInput, fmt.Errorf("deal %s is not in the deal store, pass its --provider", dealUuid))
+	}
+	if rec != nil {
+		client, err := address.NewFromString(rec.Client)
+		if err == nil && client.Protocol() == address.Delegated {
+			return unverifiableStatusError(dealUuid, rec, nil)
+		}
+	}
+
+	gapi, closer, err := lcli.GetGatewayAPI(cctx)
+	if err != nil {
+		return wtypes.WithCode(wtypes.ErrCodeRPC, fmt.Errorf("cant setup gateway connection: %w", err))
+	}
+	defer closer()
+
+	n, err := clinode.Setup(cctx.String("repo"))
+	if err != nil {
+		return err
+	}
+	walletAddr, err := n.GetProvidedOrDefaultWallet(ctx, wallet)
+	if err != nil {
+		return err
+	}
+
+	maddr, err := address.NewFromString(provider)
+	if err != nil {
+		return wtypes.WithCode(wtypes.ErrCodeInvalidInput, err)
+	}
+	addrInfo, err := cmd.GetAddrInfo(ctx, gapi, maddr)
+	if err != nil {
+		return err
+	}
+
+	sign := func(ctx context.Context, msg []byte) (*crypto.Signature, error) {
+		return n.Wallet.WalletSign(ctx, walletAddr, msg, api.MsgMeta{Type: api.MTUnknown})
+	}
+
+	var last string
+	for {
+		resp, err := getDealStatusRetrying(ctx, n.Host, *addrInfo, dealUuid, sign, 5*time.Second)
+		if errors.Is(err, errStatusUnverifiable) {
+			return unverifiableStatusError(dealUuid, rec, err)
+		}
+		if err != nil {
+			return wtypes.WithCode(wtypes.ErrCodeRPC, fmt.Errorf("deal status request failed: %w", err))
+		}
+		state := DealStateOf(resp)
+
+		// only report the status again when it changed
+		if msg := DealStatusMessage(resp); msg != last {
+			if err := report(newDealStatusResult(provider, resp)); err != nil {
+				return err
+			}
+			last = msg
+		}
+		if rec != nil {
+			err := withDealStore(cctx.String("repo"), func(store *DealStore) error {
+				return store.Update(ctx, dealUuid, func(rec *DealRecord) { updateDealRecord(rec, resp, state) })
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update deal store: %w", err)
+			}
+		}
+
+		if !cctx.Bool("watch") || state == DealActive {
+			return nil
+		}
+		if state == DealFailed {
+			return wtypes.WithCode(wtypes.ErrCodeDealFailed, fmt.Errorf("deal %s failed: %s", dealUuid, DealStatusMessage(resp)))
+		}
+		time.Sleep(cctx.Duration("interval"))
+	}
+}
+
+// updateDealRecord stores the progress reported in resp in rec
+func updateDealRecord(rec *DealRecord, resp *types.DealStatusResponse, state DealState) {
+	st := resp.DealStatus
+	rec.Checkpoint = st.Status
+	rec.SealingStatus = st.SealingStatus
+	if st.PublishCid != nil {
+		rec.PublishCid = st.PublishCid.String()
+	}
+	if st.ChainDealID != 0 {
+		rec.DealID = uint64(st.ChainDealID)
+	}
+	switch state {
+	case DealActive:
+		rec.Status = DealStatusActive
+	case DealFailed:
+		rec.Status = DealStatusFailed
+		rec.Message = DealStatusMessage(resp)
+	}
+}
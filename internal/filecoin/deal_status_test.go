@@ -0,0 +1,242 @@
+package filecoin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/boost/storagemarket/types"
+	"github.com/filecoin-project/boost/storagemarket/types/dealcheckpoints"
+	"github.com/filecoin-project/go-address"
+	cborutil "github.com/filecoin-project/go-cbor-util"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/lotus/lib/sigs"
+	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
+	"github.com/google/uuid"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multihash"
+)
+
+// statusProvider is a stand-in provider host serving the deal status
+// protocol. It resets the stream of the first failures requests, then
+// answers with respond.
+type statusProvider struct {
+	host.Host
+	failures int
+	respond  func(req *types.DealStatusRequest) *types.DealStatusResponse
+
+	mu       sync.Mutex
+	requests int
+}
+
+func newStatusProvider(t *testing.T, failures int, respond func(req *types.DealStatusRequest) *types.DealStatusResponse) *statusProvider {
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.Close() })
+	p := &statusProvider{Host: h, failures: failures, respond: respond}
+	h.SetStreamHandler(DealStatusProtocolv120, func(s network.Stream) {
+		var req types.DealStatusRequest
+		if err := cborutil.ReadCborRPC(s, &req); err != nil {
+			t.Errorf("read deal status request: %v", err)
+			s.Reset()
+			return
+		}
+		p.mu.Lock()
+		p.requests++
+		fail := p.requests <= p.failures
+		p.mu.Unlock()
+		if fail {
+			s.Reset()
+			return
+		}
+		defer s.Close()
+		if err := cborutil.WriteCborRPC(s, respond(&req)); err != nil {
+			t.Errorf("write deal status response: %v", err)
+		}
+	})
+	return p
+}
+
+func (p *statusProvider) addrInfo() peer.AddrInfo {
+	return peer.AddrInfo{ID: p.ID(), Addrs: p.Addrs()}
+}
+
+func newTestClientHost(t *testing.T) host.Host {
+	h, err := libp2p.New(libp2p.NoListenAddrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.Close() })
+	return h
+}
+
+// testWallet holds the secp256k1 key deal status requests are signed with
+type testWallet struct {
+	key  []byte
+	addr address.Address
+}
+
+func newTestWallet(t *testing.T) *testWallet {
+	key, err := sigs.Generate(crypto.SigTypeSecp256k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := sigs.ToPublic(crypto.SigTypeSecp256k1, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := address.NewSecp256k1Address(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testWallet{key: key, addr: addr}
+}
+
+func (w *testWallet) sign(ctx context.Context, msg []byte) (*crypto.Signature, error) {
+	return sigs.Sign(crypto.SigTypeSecp256k1, w.key, msg)
+}
+
+// sealedDealStatus answers req with a deal of client sealed in a proving
+// sector. Like boost, it first verifies the request signature against the
+// account key of client, accountKey, which StateAccountKey returns as the
+// f4 address itself for a contract.
+func sealedDealStatus(client address.Address, accountKey address.Address) func(req *types.DealStatusRequest) *types.DealStatusResponse {
+	return func(req *types.DealStatusRequest) *types.DealStatusResponse {
+		uuidBytes, _ := req.DealUUID.MarshalBinary()
+		if err := sigs.Verify(&req.Signature, accountKey, uuidBytes); err != nil {
+			return &types.DealStatusResponse{DealUUID: req.DealUUID, Error: boostSignatureError}
+		}
+		provider, _ := address.NewIDAddress(1001)
+		// the proposal is not looked at, but its cids must be defined to encode
+		proposalCid, _ := cid.Prefix{Version: 1, Codec: cid.DagCBOR, MhType: multihash.SHA2_256, MhLength: -1}.Sum([]byte("proposal"))
+		resp := &types.DealStatusResponse{
+			DealUUID: req.DealUUID,
+			DealStatus: &types.DealStatus{
+				Status:            dealcheckpoints.IndexedAndAnnounced.String(),
+				SealingStatus:     "Proving",
+				SignedProposalCid: proposalCid,
+				ChainDealID:       1234,
+			},
+			TransferSize:   100,
+			NBytesReceived: 100,
+		}
+		resp.DealStatus.Proposal.Client = client
+		resp.DealStatus.Proposal.Provider = provider
+		resp.DealStatus.Proposal.PieceCID = proposalCid
+		return resp
+	}
+}
+
+// walletDealStatus is sealedDealStatus for a deal of the account of w
+func walletDealStatus(w *testWallet) func(req *types.DealStatusRequest) *types.DealStatusResponse {
+	client, _ := address.NewIDAddress(1000)
+	return sealedDealStatus(client, w.addr)
+}
+
+func TestGetDealStatus(t *testing.T) {
+	w := newTestWallet(t)
+	p := newStatusProvider(t, 0, walletDealStatus(w))
+	h := newTestClientHost(t)
+	ctx := context.Background()
+	if err := h.Connect(ctx, p.addrInfo()); err != nil {
+		t.Fatal(err)
+	}
+
+	dealUuid := uuid.New()
+	resp, err := GetDealStatus(ctx, h, p.ID(), dealUuid, w.sign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.DealUUID != dealUuid || DealStateOf(resp) != DealActive {
+		t.Fatalf("unexpected deal status %+v", resp)
+	}
+	res := newDealStatusResult("f01001", resp)
+	if res.Status != "Sealing: Proving" || res.State != "active" || res.ChainDealID != 1234 {
+		t.Fatalf("unexpected deal status result %+v", res)
+	}
+}
+
+func TestGetDealStatusRetriesStreamErrors(t *testing.T) {
+	w := newTestWallet(t)
+	p := newStatusProvider(t, 2, walletDealStatus(w))
+	h := newTestClientHost(t)
+
+	resp, err := getDealStatusRetrying(context.Background(), h, p.addrInfo(), uuid.New(), w.sign, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if DealStateOf(resp) != DealActive {
+		t.Fatalf("unexpected deal status %+v", resp)
+	}
+	if p.requests != 3 {
+		t.Fatalf("got %d requests, expected 3", p.requests)
+	}
+}
+
+func TestGetDealStatusGivesUp(t *testing.T) {
+	w := newTestWallet(t)
+	p := newStatusProvider(t, 100, walletDealStatus(w))
+	h := newTestClientHost(t)
+
+	if _, err := getDealStatusRetrying(context.Background(), h, p.addrInfo(), uuid.New(), w.sign, time.Millisecond); err == nil {
+		t.Fatal("expected the deal status request to fail")
+	}
+	if p.requests != dealStatusAttempts {
+		t.Fatalf("got %d requests, expected %d", p.requests, dealStatusAttempts)
+	}
+}
+
+func TestGetDealStatusProviderError(t *testing.T) {
+	p := newStatusProvider(t, 0, func(req *types.DealStatusRequest) *types.DealStatusResponse {
+		return &types.DealStatusResponse{DealUUID: req.DealUUID, Error: "deal not found"}
+	})
+	h := newTestClientHost(t)
+
+	_, err := getDealStatusRetrying(context.Background(), h, p.addrInfo(), uuid.New(), newTestWallet(t).sign, time.Millisecond)
+	if err == nil || err.Error() != "provider error: deal not found" {
+		t.Fatalf("expected the provider error, got %v", err)
+	}
+	if p.requests != 1 {
+		t.Fatalf("got %d requests, a provider error should not be retried", p.requests)
+	}
+}
+
+// TestGetDealStatusContractClient asks for the status of a deal made for the
+// f4 address of a contract, as the deals of the wrapper contract are
+func TestGetDealStatusContractClient(t *testing.T) {
+	client, err := address.NewDelegatedAddress(10, bytes.Repeat([]byte{0xaa}, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newStatusProvider(t, 0, sealedDealStatus(client, client))
+	h := newTestClientHost(t)
+
+	dealUuid := uuid.New()
+	_, err = getDealStatusRetrying(context.Background(), h, p.addrInfo(), dealUuid, newTestWallet(t).sign, time.Millisecond)
+	if !errors.Is(err, errStatusUnverifiable) {
+		t.Fatalf("expected the signature to be unverifiable, got %v", err)
+	}
+	if p.requests != 1 {
+		t.Fatalf("got %d requests, an unverifiable signature should not be retried", p.requests)
+	}
+
+	err = unverifiableStatusError(dealUuid, nil, err)
+	if !strings.Contains(err.Error(), "deals reconcile") {
+		t.Fatalf("expected the error to point to deals reconcile, got %v", err)
+	}
+	rec := &DealRecord{DealUuid: dealUuid, Client: client.String(), DealID: 1234}
+	err = unverifiableStatusError(dealUuid, rec, nil)
+	if !strings.Contains(err.Error(), client.String()) || !strings.Contains(err.Error(), "lotus state get-deal 1234") {
+		t.Fatalf("expected the error to name the contract and the deal lookup, got %v", err)
+	}
+}
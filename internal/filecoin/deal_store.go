@@ -0,0 +1,203 @@
+package filecoin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	wtypes "github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/filecoin-project/boost/storagemarket/types"
+	"github.com/google/uuid"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+	"github.com/mitchellh/go-homedir"
+)
+
+// dealStoreDir is the directory of the deal store inside the boost client repo
+const dealStoreDir = "wrappedeal-deals"
+
+const (
+	// DealStatusAccepted is a proposal the provider accepted
+	DealStatusAccepted = "accepted"
+	// DealStatusRejected is a proposal the provider rejected
+	DealStatusRejected = "rejected"
+	// DealStatusError is a proposal that was signed but could not be sent or
+	// got no answer
+	DealStatusError = "error"
+	// DealStatusActive is an accepted deal sealed in a proving sector
+	DealStatusActive = "active"
+	// DealStatusFailed is an accepted deal that failed or expired
+	DealStatusFailed = "failed"
+)
+
+var ErrDealNotFound = errors.New("deal not found")
+
+// DealRecord is everything known about a deal proposal made by MakeDeal
+type DealRecord struct {
+	DealUuid   uuid.UUID `json:"dealUuid"`
+	Provider   string    `json:"provider"`
+	PieceCid   string    `json:"pieceCid"`
+	PieceSize  uint64    `json:"pieceSize"`
+	PayloadCid string    `json:"payloadCid"`
+	CarSize    uint64    `json:"carSize"`
+	// TransferURL is empty for offline deals
+	TransferURL string `json:"transferUrl,omitempty"`
+	// Client is the f4 address of the wrapper contract the deal is made for
+	Client   string `json:"client"`
+	Signer   string `json:"signer"`
+	Contract string `json:"contract"`
+	// Params holds the signed ClientDealProposal as sent to the provider
+	Params types.DealParams `json:"params"`
+	Status string           `json:"status"`
+	// Message is the rejection reason or the error of the proposal
+	Message string `json:"message,omitempty"`
+	// Checkpoint, SealingStatus and PublishCid are the progress last
+	// reported by the provider
+	Checkpoint    string `json:"checkpoint,omitempty"`
+	SealingStatus string `json:"sealingStatus,omitempty"`
+	PublishCid    string `json:"publishCid,omitempty"`
+	// DealID is the on-chain deal ID, 0 until the deal is published
+	DealID    uint64    `json:"dealId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DealStore persists DealRecords in a leveldb datastore in the boost client
+// repo, keyed by deal UUID.
+type DealStore struct {
+	ds *leveldb.Datastore
+}
+
+// OpenDealStore opens, creating it if needed, the deal store of the boost
+// client repo at repo.
+func OpenDealStore(repo string) (*DealStore, error) {
+	repoDir, err := homedir.Expand(repo)
+	if err != nil {
+		return nil, err
+	}
+	d, err := leveldb.NewDatastore(filepath.Join(repoDir, dealStoreDir), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open deal store: %w", err)
+	}
+	return &DealStore{ds: d}, nil
+}
+
+func (s *DealStore) Close() error {
+	return s.ds.Close()
+}
+
+// withDealStore opens the deal store of repo for the time of fn only, so
+// that long running commands do not hold its lock
+func withDealStore(repo string, fn func(store *DealStore) error) error {
+	store, err := OpenDealStore(repo)
+	if err != nil {
+		return err
+	}
+	if err := fn(store); err != nil {
+		store.Close()
+		return err
+	}
+	return store.Close()
+}
+
+func dealKey(dealUuid uuid.UUID) ds.Key {
+	return ds.NewKey("/deals/" + dealUuid.String())
+}
+
+// Put saves rec, setting its CreatedAt on the first save and its UpdatedAt.
+func (s *DealStore) Put(ctx context.Context, rec *DealRecord) error {
+	now := time.Now()
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = now
+	}
+	rec.UpdatedAt = now
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.ds.Put(ctx, dealKey(rec.DealUuid), b)
+}
+
+// Get returns the record of the deal with dealUuid, or ErrDealNotFound.
+func (s *DealStore) Get(ctx context.Context, dealUuid uuid.UUID) (*DealRecord, error) {
+	b, err := s.ds.Get(ctx, dealKey(dealUuid))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, wtypes.WithCode(wtypes.ErrCodeNotFound, fmt.Errorf("%w: %s", ErrDealNotFound, dealUuid))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rec DealRecord
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode deal %s: %w", dealUuid, err)
+	}
+	return &rec, nil
+}
+
+// Update applies update to the stored record of the deal with dealUuid.
+func (s *DealStore) Update(ctx context.Context, dealUuid uuid.UUID, update func(rec *DealRecord)) error {
+	rec, err := s.Get(ctx, dealUuid)
+	if err != nil {
+		return err
+	}
+	update(rec)
+	return s.Put(ctx, rec)
+}
+
+// List returns all the records, oldest first.
+func (s *DealStore) List(ctx context.Context) ([]*DealRecord, error) {
+	res, err := s.ds.Query(ctx, query.Query{Prefix: "/deals"})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var recs []*DealRecord
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		var rec DealRecord
+		if err := json.Unmarshal(r.Value, &rec); err != nil {
+			return nil, fmt.Errorf("failed to decode deal %s: %w", r.Key, err)
+		}
+		recs = append(recs, &rec)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].CreatedAt.Before(recs[j].CreatedAt)
+	})
+	return recs, nil
+}
+
+func scanKey(contract string) ds.Key {
+	return ds.NewKey("/scanned/" + strings.ToLower(contract))
+}
+
+// LastScannedBlock returns the last block of contract whose logs were
+// reconciled, and false when it was never scanned.
+func (s *DealStore) LastScannedBlock(ctx context.Context, contract string) (uint64, bool, error) {
+	b, err := s.ds.Get(ctx, scanKey(contract))
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	block, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid last scanned block %q: %w", b, err)
+	}
+	return block, true, nil
+}
+
+func (s *DealStore) SetLastScannedBlock(ctx context.Context, contract string, block uint64) error {
+	return s.ds.Put(ctx, scanKey(contract), []byte(strconv.FormatUint(block, 10)))
+}
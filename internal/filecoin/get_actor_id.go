@@ -3,8 +3,8 @@ package filecoin
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	"github.com/filecoin-project/boost/cli/node"
@@ -13,8 +13,14 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-// GetEthAddr converts a Filecoin address to its corresponding Ethereum address using the Boost node.
-func GetActorIdAction(cctx *cli.Context) error {
+// ActorIdResult is the actor ID of a wallet address
+type ActorIdResult struct {
+	Address string `json:"address"`
+	ActorId string `json:"actorId"`
+}
+
+// GetActorIdAction looks up the actor ID of a wallet of the Boost node.
+func GetActorIdAction(cctx *cli.Context) (*ActorIdResult, error) {
 
 	ctx := context.Background()
 	filecoinAddrStr := cctx.String("filecoin-addr")
@@ -23,39 +29,36 @@ func GetActorIdAction(cctx *cli.Context) error {
 	// Expand the repo path
 	repoPath, err := utils.ExpandPath(repoFlag)
 	if err != nil {
-		return fmt.Errorf("failed to expand repo path: %v", err)
+		return nil, fmt.Errorf("failed to expand repo path: %v", err)
 	}
 
 	api, closer, err := lcli.GetGatewayAPI(cctx)
 	if err != nil {
-		return fmt.Errorf("cant setup gateway connection: %w", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("cant setup gateway connection: %w", err))
 	}
 	defer closer()
 	
 	// Set up the Boost node
 	n, err := node.Setup(repoPath)
 	if err != nil {
-		log.Fatalf("Failed to set up Boost node: %v", err)
-		return err
+		return nil, fmt.Errorf("failed to set up Boost node: %w", err)
 	}
 
 	walletAddr, err := n.GetProvidedOrDefaultWallet(ctx, filecoinAddrStr)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fmt.Println("Using Filecoin Address:", walletAddr.String())
 
 	signerActorId, err := api.StateLookupID(ctx, walletAddr, chain_types.EmptyTSK)
 	if err != nil {
-		return fmt.Errorf("failed to lookup actorId for signer address: %w", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("failed to lookup actorId for signer address: %w", err))
 	}
 	stringActorId, err := utils.GetStringActorId(signerActorId)
 	if err != nil {
-		return fmt.Errorf("failed to convert actorId to string: %w", err)
+		return nil, fmt.Errorf("failed to convert actorId to string: %w", err)
 	}
 
-	fmt.Println("Actor ID:", stringActorId)
-
-	return nil
+	return &ActorIdResult{Address: walletAddr.String(), ActorId: stringActorId}, nil
 }
@@ -3,7 +3,8 @@ package filecoin
 import (
 	"context"
 	"fmt"
-	"log"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -14,19 +15,24 @@ import (
 	"golang.org/x/crypto/blake2b"
 )
 
+// EthAddrResult is the Ethereum address derived from a Filecoin address
+type EthAddrResult struct {
+	FilecoinAddress string         `json:"filecoinAddress"`
+	EthAddress      common.Address `json:"ethAddress"`
+}
+
 // GetEthAddr converts a Filecoin address to its corresponding Ethereum address using the Boost node.
-func GetEthAddr(ctx context.Context, filecoinAddrStr string, repoPath string) error {
+func GetEthAddr(ctx context.Context, filecoinAddrStr string, repoPath string) (*EthAddrResult, error) {
 	// Convert string to address.Address
 	filecoinAddr, err := address.NewFromString(filecoinAddrStr)
 	if err != nil {
-		return fmt.Errorf("invalid Filecoin address: %v", err)
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("invalid Filecoin address: %v", err))
 	}
 
 	// Set up the Boost node
 	n, err := node.Setup(repoPath)
 	if err != nil {
-		log.Fatalf("Failed to set up Boost node: %v", err)
-		return err
+		return nil, fmt.Errorf("failed to set up Boost node: %w", err)
 	}
 
 	fmt.Println("Using Filecoin Address:", filecoinAddr)
@@ -34,12 +40,10 @@ func GetEthAddr(ctx context.Context, filecoinAddrStr string, repoPath string) er
 	// Derive the Ethereum address
 	ethAddress, err := DeriveEthAddr(ctx, n, filecoinAddr)
 	if err != nil {
-		return fmt.Errorf("failed to derive Ethereum address: %v", err)
+		return nil, fmt.Errorf("failed to derive Ethereum address: %w", err)
 	}
 
-	fmt.Println("Derived Ethereum Address:", ethAddress.Hex())
-
-	return nil
+	return &EthAddrResult{FilecoinAddress: filecoinAddr.String(), EthAddress: ethAddress}, nil
 }
 
 func DeriveEthAddr(ctx context.Context, n *node.Node, filecoinAddr address.Address) (common.Address, error) {
@@ -57,8 +61,8 @@ func DeriveEthAddr(ctx context.Context, n *node.Node, filecoinAddr address.Addre
 	// Sign the dummy data using the Boost wallet
 	sig, err := n.Wallet.WalletSign(ctx, filecoinAddr, dummyBuf, api.MsgMeta{Type: api.MTUnknown})
 	if err != nil {
-		log.Fatalf("Failed to sign data: %v", err)
-		return common.Address{}, err
+		// most likely an address the wallet holds no key for
+		return common.Address{}, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("failed to sign data with %s: %w", filecoinAddr, err))
 	}
 
 	// Recover the public key from the signature and hash
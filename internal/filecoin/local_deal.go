@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 	"github.com/eastore-project/fil-deal-wrapper/internal/utils"
 
 	lcli "github.com/filecoin-project/lotus/cli"
@@ -13,32 +14,39 @@ import (
 )
 
 
-func LocalDealCmdAction(cctx *cli.Context, isOnline bool) error {
+func LocalDealCmdAction(cctx *cli.Context, isOnline bool) (*DealSummary, error) {
 	ctx := context.Background()
 
 	api, closer, err := lcli.GetGatewayAPI(cctx)
 	if err != nil {
-		return fmt.Errorf("cant setup gateway connection: %w", err)
+		return nil, types.WithCode(types.ErrCodeRPC, fmt.Errorf("cant setup gateway connection: %w", err))
 	}
 	defer closer()
 
 	// Retrieve flags
 	path := cctx.String("path")
-	useLighthouse := cctx.Bool("lighthouse")
-	apiKey := cctx.String("apikey")
 	pieceSize := cctx.Uint64("piece-size")
 	// return error if pieceSize is not a power of 2
 	if pieceSize != 0 && (pieceSize&(pieceSize-1)) != 0 {
-		return fmt.Errorf("piece-size must be a power of 2")
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("piece-size must be a power of 2"))
 	}
 
 	// Validate the provided path
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return fmt.Errorf("the provided path does not exist: %s", path)
+		return nil, types.WithCode(types.ErrCodeInvalidInput, fmt.Errorf("the provided path does not exist: %s", path))
 	}
 
 	outDir := cctx.String("out-dir")
 
+	dagParams, err := utils.DagParamsFromFlags(cctx)
+	if err != nil {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, err)
+	}
+	host, err := utils.CarHostFromFlags(cctx)
+	if err != nil {
+		return nil, types.WithCode(types.ErrCodeInvalidInput, err)
+	}
+
 	// Generate CAR file
 	carParams := utils.CarParams{
 		Input:     path,
@@ -47,10 +55,11 @@ func LocalDealCmdAction(cctx *cli.Context, isOnline bool) error {
 		PieceSize: pieceSize, // Will be set based on generated CAR
 		Parent:    path,
 		TmpDir:    "",
+		Dag:       dagParams,
 	}
 	result, err := carParams.GenerateCarUtil()
 	if err != nil {
-		return fmt.Errorf("failed to generate CAR: %v", err)
+		return nil, fmt.Errorf("failed to generate CAR: %v", err)
 	}
 
 	commp := result.PieceCid
@@ -58,34 +67,49 @@ func LocalDealCmdAction(cctx *cli.Context, isOnline bool) error {
 	carFilePath := filepath.Join(outDir, fmt.Sprintf("%s.car", commp))
 	carFileInfo, err := os.Stat(carFilePath)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve CAR file info: %v", err)
+		return nil, fmt.Errorf("failed to retrieve CAR file info: %v", err)
 	}
 	carSize := uint64(carFileInfo.Size())
+	fmt.Printf("Manifest written to: %s\n", result.ManifestPath)
 
-	var httpURL string
-	if useLighthouse {
-		if apiKey == "" {
-			apiKey = os.Getenv("LIGHTHOUSE_API_KEY")
-			if apiKey == "" {
-				return fmt.Errorf("API key for Lighthouse is required when using --lighthouse flag")
+	httpURL := cctx.String("http-url")
+	httpHeaders := cctx.StringSlice("http-headers")
+	var providerHeaders map[string][]string
+	keepCar := false
+	skipURLCheck := cctx.Bool("skip-url-check")
+	if cctx.Bool("dry-run") {
+		// nothing is sent to the providers, so the CAR is neither hosted
+		// nor deleted and the proposals point at the local file instead
+		keepCar = true
+		if host != nil {
+			absPath, err := filepath.Abs(carFilePath)
+			if err != nil {
+				return nil, err
 			}
+			httpURL = "file://" + absPath
+			skipURLCheck = true
 		}
-		uploadResp, err := utils.UploadToLighthouse(carFilePath, apiKey)
+	} else if host != nil {
+		// host the car once for every provider it is proposed to
+		hosted, err := host.Host(ctx, carFilePath, cctx.StringSlice("provider"))
 		if err != nil {
-			return fmt.Errorf("failed to upload to Lighthouse: %v", err)
+			return nil, fmt.Errorf("failed to host CAR: %v", err)
 		}
-		httpURL = fmt.Sprintf("https://gateway.lighthouse.storage/ipfs/%s", uploadResp.Hash)
-		fmt.Printf("Car Uploaded to Lighthouse: %s\n", httpURL)
-	} else {
-		httpURL = cctx.String("http-url")
+		httpURL = hosted.URL
+		httpHeaders = append(httpHeaders, hosted.Headers...)
+		providerHeaders = hosted.ProviderHeaders
+		keepCar = hosted.Local
+		fmt.Printf("Car hosted at: %s\n", httpURL)
 	}
-	// delete the local car file
-	err = os.Remove(carFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to delete local car file: %v", err)
+	if !keepCar {
+		// delete the local car file
+		err = os.Remove(carFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete local car file: %v", err)
+		}
 	}
 
-	err = MakeDeal(
+	summary, err := MakeDeal(
 		ctx,
 		api,
 		cctx.String("repo"),
@@ -93,7 +117,8 @@ func LocalDealCmdAction(cctx *cli.Context, isOnline bool) error {
 		commp,
 		result.DataCid,
 		httpURL,
-		cctx.String("provider"),
+		cctx.StringSlice("provider"),
+		cctx.Int("replicas"),
 		result.PieceSize,
 		carSize,
 		cctx.Int("start-epoch-head-offset"),
@@ -105,11 +130,17 @@ func LocalDealCmdAction(cctx *cli.Context, isOnline bool) error {
 		cctx.Bool("remove-unsealed-copy"),
 		cctx.Bool("skip-ipni-announce"),
 		true,
-		cctx.StringSlice("http-headers"),
+		httpHeaders,
+		providerHeaders,
+		skipURLCheck,
+		cctx.Bool("verify-url-commp"),
+		cctx.Bool("skip-preflight"),
+		cctx.Bool("dry-run"),
+		cctx.String("dry-run-dir"),
 		cctx.String("contract"),
 	)
 	if err != nil {
-		return fmt.Errorf("deal failed: %w", err)
+		return summary, fmt.Errorf("deal failed: %w", err)
 	}
-	return nil
+	return summary, nil
 }
@@ -0,0 +1,166 @@
+package filecoin
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/bindings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/lotus/api"
+	chain_types "github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+)
+
+// erc20BalanceABI holds the ERC20 method the pre-flight checks call
+const erc20BalanceABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+// PreflightDeal checks with the wrapper contract that deals for pieceSize
+// bytes over duration epochs, signed by signerActorId, will not revert in
+// authenticateMessage or dealNotify once published: the signer must be
+// whitelisted and every provider registered, and the contract must hold
+// enough of each provider's payment token to pay for replicas of the deals.
+// Verified deals also need enough DataCap on the contract's f4 address.
+func PreflightDeal(
+	ctx context.Context,
+	api api.Gateway,
+	contract common.Address,
+	signerActorId uint64,
+	providers []address.Address,
+	replicas int,
+	pieceSize uint64,
+	duration int,
+	verified bool,
+) error {
+	wrapper, err := bindings.MarketDealWrapperMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	erc20, err := abi.JSON(strings.NewReader(erc20BalanceABI))
+	if err != nil {
+		return err
+	}
+	call := func(to common.Address, method string, input []byte) ([]byte, error) {
+		toAddr := ethtypes.EthAddress(to)
+		output, err := api.EthCall(ctx, ethtypes.EthCall{To: &toAddr, Data: input}, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+		if err != nil {
+			return nil, fmt.Errorf("%s call failed: %w", method, err)
+		}
+		return output, nil
+	}
+
+	input, err := wrapper.Pack("isWhitelisted", signerActorId)
+	if err != nil {
+		return err
+	}
+	output, err := call(contract, "isWhitelisted", input)
+	if err != nil {
+		return err
+	}
+	res, err := wrapper.Unpack("isWhitelisted", output)
+	if err != nil {
+		return fmt.Errorf("failed to unpack isWhitelisted result: %w", err)
+	}
+	if !res[0].(bool) {
+		return fmt.Errorf("signer actor id %d is not whitelisted in contract %s", signerActorId, contract)
+	}
+
+	// what each payment token has to cover, one deal per provider
+	costs := make(map[common.Address][]*big.Int)
+	for _, provider := range providers {
+		idAddr, err := api.StateLookupID(ctx, provider, chain_types.EmptyTSK)
+		if err != nil {
+			return fmt.Errorf("failed to lookup actor id of provider %s: %w", provider, err)
+		}
+		actorId, err := address.IDFromAddress(idAddr)
+		if err != nil {
+			return err
+		}
+		input, err := wrapper.Pack("getSpFromId", actorId)
+		if err != nil {
+			return err
+		}
+		output, err := call(contract, "getSpFromId", input)
+		if err != nil {
+			return err
+		}
+		res, err := wrapper.Unpack("getSpFromId", output)
+		if err != nil {
+			return fmt.Errorf("failed to unpack getSpFromId result: %w", err)
+		}
+		sp := *abi.ConvertType(res[0], new(bindings.MarketDealWrapperStorageProvider)).(*bindings.MarketDealWrapperStorageProvider)
+		if sp.EthAddr == (common.Address{}) {
+			return fmt.Errorf("storage provider %s is not registered in contract %s", provider, contract)
+		}
+		// the payment dealNotify sets up for the deal
+		cost := new(big.Int).SetUint64(pieceSize)
+		cost.Mul(cost, sp.PricePerBytePerEpoch)
+		cost.Mul(cost, big.NewInt(int64(duration)))
+		costs[sp.Token] = append(costs[sp.Token], cost)
+	}
+
+	for token, tokenCosts := range costs {
+		// at most replicas deals are made, assume the most expensive ones
+		sort.Slice(tokenCosts, func(i, j int) bool { return tokenCosts[i].Cmp(tokenCosts[j]) > 0 })
+		required := new(big.Int)
+		for _, cost := range tokenCosts[:min(replicas, len(tokenCosts))] {
+			required.Add(required, cost)
+		}
+
+		var balance *big.Int
+		name := "native"
+		if token == (common.Address{}) {
+			bal, err := api.EthGetBalance(ctx, ethtypes.EthAddress(contract), ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+			if err != nil {
+				return fmt.Errorf("failed to get contract balance: %w", err)
+			}
+			balance = bal.Int
+		} else {
+			name = "token " + token.Hex()
+			input, err := erc20.Pack("balanceOf", contract)
+			if err != nil {
+				return err
+			}
+			output, err := call(token, "balanceOf", input)
+			if err != nil {
+				return err
+			}
+			res, err := erc20.Unpack("balanceOf", output)
+			if err != nil {
+				return fmt.Errorf("failed to unpack balanceOf result: %w", err)
+			}
+			balance = res[0].(*big.Int)
+		}
+		if balance.Cmp(required) < 0 {
+			return fmt.Errorf("contract %s balance of %s is %s, the deals need %s", name, contract, balance, required)
+		}
+	}
+
+	if verified {
+		f4, err := address.NewDelegatedAddress(builtin.EthereumAddressManagerActorID, contract[:])
+		if err != nil {
+			return err
+		}
+		dataCap, err := api.StateVerifiedClientStatus(ctx, f4, chain_types.EmptyTSK)
+		if err != nil {
+			return fmt.Errorf("failed to get datacap of %s: %w", f4, err)
+		}
+		required := new(big.Int).Mul(new(big.Int).SetUint64(pieceSize), big.NewInt(int64(replicas)))
+		if dataCap == nil || dataCap.Int.Cmp(required) < 0 {
+			have := "no"
+			if dataCap != nil {
+				have = dataCap.String()
+			}
+			return fmt.Errorf("contract %s has %s datacap, the verified deals need %s", f4, have, required)
+		}
+	}
+	return nil
+}
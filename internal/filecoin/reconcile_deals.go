@@ -0,0 +1,187 @@
+package filecoin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ethtypes "github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/filecoin-project/go-state-types/builtin/v9/market"
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/datamodel"
+)
+
+const (
+	// logBatchSize is the number of blocks queried per eth_getLogs call,
+	// below the 2880 epochs lotus allows by default
+	logBatchSize = 2000
+	// blockTime is the time between two Filecoin epochs
+	blockTime = 30 * time.Second
+)
+
+// DealNotification is a DealNotify event emitted by the wrapper contract when
+// the market actor notifies it of a published deal
+type DealNotification struct {
+	DealID      uint64
+	Proposal    market.DealProposal
+	BlockNumber uint64
+	TxHash      common.Hash
+}
+
+// DecodeDealNotifyParams decodes the CBOR MarketDealNotifyParams the market
+// actor sends to the contract, the tuple (deal proposal bytes, deal id).
+func DecodeDealNotifyParams(data []byte) (uint64, *market.DealProposal, error) {
+	nd, err := ipld.Decode(data, dagcbor.Decode)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to decode deal notify params: %w", err)
+	}
+	if nd.Kind() != datamodel.Kind_List || nd.Length() != 2 {
+		return 0, nil, errors.New("deal notify params are not a 2-tuple")
+	}
+	proposalNode, err := nd.LookupByIndex(0)
+	if err != nil {
+		return 0, nil, err
+	}
+	proposalBytes, err := proposalNode.AsBytes()
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid deal proposal: %w", err)
+	}
+	dealIDNode, err := nd.LookupByIndex(1)
+	if err != nil {
+		return 0, nil, err
+	}
+	dealID, err := dealIDNode.AsInt()
+	if err != nil || dealID < 0 {
+		return 0, nil, fmt.Errorf("invalid deal id: %v", err)
+	}
+
+	var proposal market.DealProposal
+	if err := proposal.UnmarshalCBOR(bytes.NewReader(proposalBytes)); err != nil {
+		return 0, nil, fmt.Errorf("failed to decode deal proposal: %w", err)
+	}
+	return uint64(dealID), &proposal, nil
+}
+
+// FetchDealNotifications returns the DealNotify events emitted by the
+// contract of client between fromBlock and toBlock included.
+func FetchDealNotifications(ctx context.Context, client *ethtypes.ETHClient, fromBlock uint64, toBlock uint64) ([]DealNotification, error) {
+	var notifications []DealNotification
+	for start := fromBlock; start <= toBlock; start += logBatchSize {
+		end := min(start+logBatchSize-1, toBlock)
+		it, err := client.Contract.FilterDealNotify(&bind.FilterOpts{Start: start, End: &end, Context: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs of blocks %d-%d: %w", start, end, err)
+		}
+		for it.Next() {
+			ev := it.Event
+			dealID, proposal, err := DecodeDealNotifyParams(ev.Data)
+			if err != nil {
+				it.Close()
+				return nil, fmt.Errorf("DealNotify event of tx %s: %w", ev.Raw.TxHash, err)
+			}
+			notifications = append(notifications, DealNotification{
+				DealID:      dealID,
+				Proposal:    *proposal,
+				BlockNumber: ev.Raw.BlockNumber,
+				TxHash:      ev.Raw.TxHash,
+			})
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack DealNotify events of blocks %d-%d: %w", start, end, err)
+		}
+	}
+	return notifications, nil
+}
+
+// proposalMatches tells whether the published proposal p is the proposal
+// rec was made with, comparing piece CID, provider, label and start epoch.
+func proposalMatches(rec *DealRecord, p *market.DealProposal) bool {
+	own := rec.Params.ClientDealProposal.Proposal
+	return own.PieceCID.Equals(p.PieceCID) &&
+		own.Provider == p.Provider &&
+		own.Label.Equals(p.Label) &&
+		own.StartEpoch == p.StartEpoch
+}
+
+// ReconcileDeals scans the DealNotify events of the contract of client and
+// attaches the deal ID of every published deal to its record in store. The
+// scan resumes after the last block scanned for the contract; without one,
+// or when fromBlock is non-zero, it starts at fromBlock or at the block of
+// the oldest unresolved proposal. It returns the records that were resolved.
+func ReconcileDeals(ctx context.Context, store *DealStore, client *ethtypes.ETHClient, fromBlock uint64) ([]*DealRecord, error) {
+	contract := client.ContractAddr.Hex()
+
+	recs, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var unresolved []*DealRecord
+	for _, rec := range recs {
+		if rec.DealID == 0 && strings.EqualFold(common.HexToAddress(rec.Contract).Hex(), contract) &&
+			rec.Status != DealStatusRejected && rec.Status != DealStatusError {
+			unresolved = append(unresolved, rec)
+		}
+	}
+
+	head, err := client.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+	toBlock := head.Number.Uint64()
+
+	if fromBlock == 0 {
+		last, ok, err := store.LastScannedBlock(ctx, contract)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case ok:
+			fromBlock = last + 1
+		case len(unresolved) > 0:
+			// deals are published after they are proposed
+			elapsed := uint64(time.Since(unresolved[0].CreatedAt) / blockTime)
+			if elapsed < toBlock {
+				fromBlock = toBlock - elapsed
+			}
+		default:
+			fromBlock = toBlock + 1
+		}
+	}
+
+	var resolved []*DealRecord
+	if fromBlock <= toBlock {
+		fmt.Println("scanning contract logs", "contract", contract, "from block", fromBlock, "to block", toBlock)
+		notifications, err := FetchDealNotifications(ctx, client, fromBlock, toBlock)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range notifications {
+			for _, rec := range unresolved {
+				if rec.DealID != 0 || !proposalMatches(rec, &n.Proposal) {
+					continue
+				}
+				dealID := n.DealID
+				if err := store.Update(ctx, rec.DealUuid, func(rec *DealRecord) { rec.DealID = dealID }); err != nil {
+					return nil, err
+				}
+				rec.DealID = dealID
+				resolved = append(resolved, rec)
+				break
+			}
+		}
+	}
+
+	if err := store.SetLastScannedBlock(ctx, contract, toBlock); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
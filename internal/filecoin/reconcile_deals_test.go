@@ -0,0 +1,132 @@
+package filecoin
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/filecoin-project/boost/storagemarket/types"
+	"github.com/filecoin-project/go-address"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/v9/market"
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/codec/dagcbor"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/fluent/qp"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+func testDealProposal(t *testing.T) market.DealProposal {
+	pieceCid, err := commcid.DataCommitmentV1ToCID(bytes.Repeat([]byte{0x11}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := address.NewDelegatedAddress(10, bytes.Repeat([]byte{0xaa}, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider, _ := address.NewIDAddress(1001)
+	label, err := market.NewLabelFromString("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return market.DealProposal{
+		PieceCID:             pieceCid,
+		PieceSize:            2048,
+		VerifiedDeal:         true,
+		Client:               client,
+		Provider:             provider,
+		Label:                label,
+		StartEpoch:           100000,
+		EndEpoch:             700000,
+		StoragePricePerEpoch: abi.NewTokenAmount(0),
+		ProviderCollateral:   abi.NewTokenAmount(0),
+		ClientCollateral:     abi.NewTokenAmount(0),
+	}
+}
+
+// encodeDealNotifyParams encodes the MarketNotifyDealParams the market actor
+// sends the client of a published deal: the tuple of the CBOR proposal, as a
+// byte string, and the deal ID.
+func encodeDealNotifyParams(t *testing.T, p market.DealProposal, dealID int64) []byte {
+	var proposal bytes.Buffer
+	if err := p.MarshalCBOR(&proposal); err != nil {
+		t.Fatal(err)
+	}
+	nd, err := qp.BuildList(basicnode.Prototype.Any, 2, func(la datamodel.ListAssembler) {
+		qp.ListEntry(la, qp.Bytes(proposal.Bytes()))
+		qp.ListEntry(la, qp.Int(dealID))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ipld.Encode(nd, dagcbor.Encode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecodeDealNotifyParams(t *testing.T) {
+	proposal := testDealProposal(t)
+	dealID, decoded, err := DecodeDealNotifyParams(encodeDealNotifyParams(t, proposal, 1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dealID != 1234 {
+		t.Fatalf("got deal id %d, expected 1234", dealID)
+	}
+	if !decoded.PieceCID.Equals(proposal.PieceCID) || decoded.Client != proposal.Client ||
+		decoded.Provider != proposal.Provider || !decoded.Label.Equals(proposal.Label) ||
+		decoded.StartEpoch != proposal.StartEpoch || decoded.EndEpoch != proposal.EndEpoch ||
+		!decoded.VerifiedDeal || decoded.PieceSize != proposal.PieceSize {
+		t.Fatalf("decoded proposal %+v, expected %+v", decoded, proposal)
+	}
+}
+
+func TestDecodeDealNotifyParamsInvalid(t *testing.T) {
+	proposal := testDealProposal(t)
+	var proposalBytes bytes.Buffer
+	if err := proposal.MarshalCBOR(&proposalBytes); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{
+		"not cbor":         {0xff, 0x00},
+		"proposal only":    proposalBytes.Bytes(),
+		"negative deal id": encodeDealNotifyParams(t, proposal, -1),
+		"truncated":        encodeDealNotifyParams(t, proposal, 1234)[:20],
+	} {
+		if _, _, err := DecodeDealNotifyParams(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestProposalMatches(t *testing.T) {
+	rec := &DealRecord{Params: types.DealParams{ClientDealProposal: market.ClientDealProposal{Proposal: testDealProposal(t)}}}
+	_, published, err := DecodeDealNotifyParams(encodeDealNotifyParams(t, testDealProposal(t), 1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proposalMatches(rec, published) {
+		t.Fatal("expected the published proposal to match its record")
+	}
+
+	otherLabel, _ := market.NewLabelFromString("bafkqaaa")
+	otherProvider, _ := address.NewIDAddress(1002)
+	for name, change := range map[string]func(p *market.DealProposal){
+		"label":       func(p *market.DealProposal) { p.Label = otherLabel },
+		"provider":    func(p *market.DealProposal) { p.Provider = otherProvider },
+		"start epoch": func(p *market.DealProposal) { p.StartEpoch++ },
+	} {
+		p := testDealProposal(t)
+		change(&p)
+		_, published, err := DecodeDealNotifyParams(encodeDealNotifyParams(t, p, 1234))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proposalMatches(rec, published) {
+			t.Errorf("a proposal with another %s matches the record", name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package types
+
+import "errors"
+
+// Error codes reported with the errors of the --output json mode
+const (
+	// ErrCodeInvalidInput is a missing or malformed argument or flag
+	ErrCodeInvalidInput = "invalid_input"
+	// ErrCodeNotFound is a deal or record that does not exist
+	ErrCodeNotFound = "not_found"
+	// ErrCodeRPC is a failed call to the lotus gateway or the eth rpc
+	ErrCodeRPC = "rpc_error"
+	// ErrCodeTxFailed is a transaction that was mined but reverted
+	ErrCodeTxFailed = "tx_failed"
+	// ErrCodeTxDropped is a transaction that left the mempool unmined
+	ErrCodeTxDropped = "tx_dropped"
+	// ErrCodeTxTimeout is a transaction not confirmed in time
+	ErrCodeTxTimeout = "tx_timeout"
+	// ErrCodePreflight is a deal the contract would reject once published
+	ErrCodePreflight = "preflight_failed"
+	// ErrCodeDealRejected is a deal that not enough providers accepted
+	ErrCodeDealRejected = "deal_rejected"
+	// ErrCodeDealFailed is an accepted deal that failed or expired
+	ErrCodeDealFailed = "deal_failed"
+	// ErrCodeUnknown is any other error
+	ErrCodeUnknown = "error"
+)
+
+// CodedError is an error tagged with one of the ErrCode constants
+type CodedError struct {
+	Code string
+	Err  error
+}
+
+func (e *CodedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// WithCode tags err with code, returning nil for a nil err.
+func WithCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CodedError{Code: code, Err: err}
+}
+
+// ErrorCode returns the code of the outermost CodedError wrapped by err, or
+// ErrCodeUnknown.
+func ErrorCode(err error) string {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ErrCodeUnknown
+}
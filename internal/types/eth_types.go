@@ -1,12 +1,16 @@
 package types
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"math/big"
 
+	"github.com/eastore-project/fil-deal-wrapper/internal/bindings"
+
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -14,7 +18,7 @@ import (
 type TransactionOptions struct {
 	FromAddress     common.Address
 	PrivateKey      *ecdsa.PrivateKey
-	GasPrice        *big.Int
+	Fees            TxFees
 	GasLimit        uint64
 	Nonce           uint64
 	ChainID         *big.Int
@@ -30,14 +34,51 @@ type ETHClient struct {
 	Client       *ethclient.Client
 	PrivateKey   *ecdsa.PrivateKey
 	FromAddress  common.Address
+	Contract     *bindings.MarketDealWrapper
 	ContractABI  abi.ABI
 	ContractAddr common.Address
 	ChainID      *big.Int
-	Nonce        uint64
-	GasPrice     *big.Int
+	Fees         TxFees
+	Txs          TxSender
+	// GasLimitMultiplier scales the estimated gas limits of transactions
+	GasLimitMultiplier float64
+}
+
+// TxSender sends the transactions of an ETHClient, see eth.TxManager
+type TxSender interface {
+	// Send gives opts the next nonce of the account, then signs and sends
+	// the transaction
+	Send(ctx context.Context, opts TransactionOptions, input []byte) (*ethTypes.Transaction, error)
+	// Wait waits for tx to be mined and sums up its receipt
+	Wait(ctx context.Context, tx *ethTypes.Transaction) (*TxResult, error)
+}
+
+// TxFees holds the gas pricing of a transaction: a gas price for legacy
+// transactions, a priority fee and a fee cap per gas for EIP-1559 ones
+type TxFees struct {
+	Legacy    bool
+	GasPrice  *big.Int
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
 }
 
 // ABIWrapper represents the structure of the ABI JSON file
 type ABIWrapper struct {
 	ABI json.RawMessage `json:"abi"`
 }
+
+// Transaction statuses of a TxResult
+const (
+	TxStatusSuccess = "success"
+	TxStatusFailed  = "failed"
+)
+
+// TxResult is the outcome of a mined contract transaction
+type TxResult struct {
+	TxHash      common.Hash `json:"txHash"`
+	Status      string      `json:"status"`
+	BlockNumber uint64      `json:"blockNumber"`
+	GasUsed     uint64      `json:"gasUsed"`
+	// RevertReason tells why a failed transaction reverted, when known
+	RevertReason string `json:"revertReason,omitempty"`
+}
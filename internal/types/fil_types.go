@@ -7,5 +7,23 @@ type CarParams struct {
 	Parent    string
 	TmpDir    string
 	Single    bool
+	// SplitSize is the target piece size used to split the input across
+	// several CARs; it is only used by GenerateSplitCarUtil.
+	SplitSize uint64
+	// Dag controls the UnixFS DAG layout; the zero value uses the defaults
+	Dag DagParams
+}
+
+// DagParams controls how files are chunked and laid out as a UnixFS DAG
+type DagParams struct {
+	// Chunker is "size-<bytes>", "rabin", "rabin-<avg>",
+	// "rabin-<min>-<avg>-<max>" or "buzhash", as accepted by ipfs add
+	Chunker string `json:"chunker"`
+	// MaxLinks is the maximum number of links per intermediate node
+	MaxLinks int `json:"maxLinks"`
+	// Layout is "balanced" or "trickle"
+	Layout     string `json:"layout"`
+	CidVersion int    `json:"cidVersion"`
+	RawLeaves  bool   `json:"rawLeaves"`
 }
 
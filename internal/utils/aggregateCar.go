@@ -0,0 +1,428 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/google/uuid"
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-car"
+)
+
+// Data segment aggregation (FRC-0058) lays sub-pieces out in a larger deal
+// piece, each at a padded offset aligned to its own padded size, and ends the
+// piece with an index of fixed size entries that lets the storage provider
+// find every sub-piece in the sealed data.
+const (
+	segmentEntrySize    = 64
+	segmentChecksumSize = 16
+	merkleNodeSize      = 32
+	aggregateExt        = ".aggregate.json"
+)
+
+// MerkleNode is a node of a piece commitment tree, hex encoded in JSON
+type MerkleNode [merkleNodeSize]byte
+
+func (n MerkleNode) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(n[:])), nil
+}
+
+func (n *MerkleNode) UnmarshalText(text []byte) error {
+	b, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	if len(b) != merkleNodeSize {
+		return fmt.Errorf("merkle node must be %d bytes, got %d", merkleNodeSize, len(b))
+	}
+	copy(n[:], b)
+	return nil
+}
+
+// ProofData is a merkle path from the node at Index on its level of the tree
+// up to the root, starting with the sibling of that node.
+type ProofData struct {
+	Index uint64       `json:"index"`
+	Path  []MerkleNode `json:"path"`
+}
+
+// InclusionProof proves that a sub-piece is part of an aggregate: Subtree
+// leads from the sub-piece commP to the aggregate commP and Index leads from
+// the sub-piece's data segment index entry to the aggregate commP.
+type InclusionProof struct {
+	Subtree ProofData `json:"proofSubtree"`
+	Index   ProofData `json:"proofIndex"`
+}
+
+// SubPiece is a CAR packed into an aggregate
+type SubPiece struct {
+	CarPath    string `json:"carPath"`
+	PayloadCid string `json:"payloadCid"`
+	PieceCid   string `json:"pieceCid"`
+	PieceSize  uint64 `json:"pieceSize"`
+	CarSize    uint64 `json:"carSize"`
+	// Offset is the padded offset of the sub-piece in the aggregate piece
+	Offset uint64         `json:"offset"`
+	Proof  InclusionProof `json:"inclusionProof"`
+}
+
+// AggregateResult describes an aggregate piece written by AggregateCars
+type AggregateResult struct {
+	PieceCid  string `json:"pieceCid"`
+	PieceSize uint64 `json:"pieceSize"`
+	// DataSize is the size of the aggregate file, the unpadded piece size
+	DataSize uint64 `json:"dataSize"`
+	// IndexOffset is the padded offset of the data segment index
+	IndexOffset  uint64     `json:"indexOffset"`
+	SubPieces    []SubPiece `json:"subPieces"`
+	Path         string     `json:"-"`
+	ManifestPath string     `json:"-"`
+}
+
+// AggregateCars packs the CARs at carPaths into one aggregate piece of
+// pieceSize bytes, or of the smallest size that fits them when pieceSize is
+// zero. The aggregate is written to outDir as <commP>.dat along with a
+// <commP>.aggregate.json manifest holding the inclusion proof of every CAR.
+func AggregateCars(outDir string, pieceSize uint64, carPaths []string) (*AggregateResult, error) {
+	if len(carPaths) == 0 {
+		return nil, fmt.Errorf("no CAR files to aggregate")
+	}
+	if pieceSize != 0 && pieceSize&(pieceSize-1) != 0 {
+		return nil, fmt.Errorf("piece-size must be a power of 2")
+	}
+
+	subPieces := make([]SubPiece, 0, len(carPaths))
+	for _, carPath := range carPaths {
+		sp, err := loadSubPiece(carPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", carPath, err)
+		}
+		subPieces = append(subPieces, sp)
+	}
+
+	// larger sub-pieces first keeps the alignment gaps small
+	sort.SliceStable(subPieces, func(i, j int) bool {
+		return subPieces[i].PieceSize > subPieces[j].PieceSize
+	})
+	var end uint64
+	for i := range subPieces {
+		size := subPieces[i].PieceSize
+		subPieces[i].Offset = (end + size - 1) / size * size
+		end = subPieces[i].Offset + size
+	}
+
+	if pieceSize == 0 {
+		pieceSize = 1 << bits.Len64(end-1)
+		for end > segmentIndexOffset(pieceSize) {
+			pieceSize <<= 1
+		}
+	}
+	indexOffset := segmentIndexOffset(pieceSize)
+	if end > indexOffset {
+		return nil, fmt.Errorf("sub-pieces need %d bytes but a piece of %d bytes only has room for %d before its index", end, pieceSize, indexOffset)
+	}
+	if uint(len(subPieces)) > maxSegmentEntries(pieceSize) {
+		return nil, fmt.Errorf("%d sub-pieces exceed the %d index entries of a piece of %d bytes", len(subPieces), maxSegmentEntries(pieceSize), pieceSize)
+	}
+
+	index := make([]byte, maxSegmentEntries(pieceSize)*segmentEntrySize)
+	tree := newPieceTree(pieceSize)
+	for i, sp := range subPieces {
+		entry, err := segmentEntry(sp)
+		if err != nil {
+			return nil, err
+		}
+		copy(index[i*segmentEntrySize:], entry)
+		tree.set(treeLevel(sp.PieceSize), sp.Offset/sp.PieceSize, entry.commDs())
+	}
+	for i := 0; i < len(index)/merkleNodeSize; i++ {
+		var n MerkleNode
+		copy(n[:], index[i*merkleNodeSize:])
+		tree.set(0, indexOffset/merkleNodeSize+uint64(i), n)
+	}
+	root := tree.build()
+
+	for i := range subPieces {
+		sp := &subPieces[i]
+		level := treeLevel(sp.PieceSize)
+		sp.Proof.Subtree = tree.proof(level, sp.Offset/sp.PieceSize)
+		sp.Proof.Index = tree.proof(1, (indexOffset+uint64(i)*segmentEntrySize)/segmentEntrySize)
+	}
+
+	outPath := filepath.Join(outDir, uuid.New().String()+".dat")
+	if err := writeAggregate(outPath, pieceSize, subPieces, indexOffset, index, root); err != nil {
+		os.Remove(outPath)
+		return nil, err
+	}
+
+	pieceCid, err := commcid.DataCommitmentV1ToCID(root[:])
+	if err != nil {
+		return nil, err
+	}
+	result := &AggregateResult{
+		PieceCid:    pieceCid.String(),
+		PieceSize:   pieceSize,
+		DataSize:    pieceSize / 128 * 127,
+		IndexOffset: indexOffset,
+		SubPieces:   subPieces,
+		Path:        filepath.Join(outDir, pieceCid.String()+".dat"),
+	}
+	if err := os.Rename(outPath, result.Path); err != nil {
+		return nil, err
+	}
+
+	manifestBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal aggregate manifest: %w", err)
+	}
+	result.ManifestPath = filepath.Join(outDir, result.PieceCid+aggregateExt)
+	if err := os.WriteFile(result.ManifestPath, manifestBytes, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write aggregate manifest: %w", err)
+	}
+	return result, nil
+}
+
+// loadSubPiece reads the root and computes the commP of the CAR at carPath
+func loadSubPiece(carPath string) (SubPiece, error) {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return SubPiece{}, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return SubPiece{}, err
+	}
+	cr, err := car.NewCarReader(f)
+	if err != nil {
+		return SubPiece{}, fmt.Errorf("failed to read car header: %w", err)
+	}
+	if len(cr.Header.Roots) != 1 {
+		return SubPiece{}, fmt.Errorf("expected exactly one root in car header, found %d", len(cr.Header.Roots))
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return SubPiece{}, err
+	}
+	pieceCid, pieceSize, err := CalcCommp(f, 0)
+	if err != nil {
+		return SubPiece{}, err
+	}
+	return SubPiece{
+		CarPath:    carPath,
+		PayloadCid: cr.Header.Roots[0].String(),
+		PieceCid:   pieceCid.String(),
+		PieceSize:  pieceSize,
+		CarSize:    uint64(stat.Size()),
+	}, nil
+}
+
+// writeAggregate writes the unpadded aggregate to outPath: every sub-piece
+// zero-padded to its piece size at its offset, zeros in the gaps and the
+// index at the end. The commP of the written data must match root.
+func writeAggregate(outPath string, pieceSize uint64, subPieces []SubPiece, indexOffset uint64, index []byte, root MerkleNode) error {
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cp := new(commp.Calc)
+	writer := bufio.NewWriterSize(io.MultiWriter(f, cp), BufSize)
+	var pos uint64
+	for _, sp := range subPieces {
+		if err := writeZeros(writer, (sp.Offset-pos)/128*127); err != nil {
+			return err
+		}
+		carF, err := os.Open(sp.CarPath)
+		if err != nil {
+			return err
+		}
+		n, err := io.Copy(writer, carF)
+		carF.Close()
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", sp.CarPath, err)
+		}
+		if err := writeZeros(writer, sp.PieceSize/128*127-uint64(n)); err != nil {
+			return err
+		}
+		pos = sp.Offset + sp.PieceSize
+	}
+	if err := writeZeros(writer, (indexOffset-pos)/128*127); err != nil {
+		return err
+	}
+	if _, err := writer.Write(unpadFr32(index)); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	rawCommP, paddedSize, err := cp.Digest()
+	if err != nil {
+		return err
+	}
+	if paddedSize != pieceSize || string(rawCommP) != string(root[:]) {
+		return fmt.Errorf("commp of the written aggregate does not match its piece tree")
+	}
+	return f.Close()
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func writeZeros(w io.Writer, n uint64) error {
+	_, err := io.CopyN(w, zeroReader{}, int64(n))
+	return err
+}
+
+// maxSegmentEntries is the number of index entries reserved at the end of a
+// deal of pieceSize bytes
+func maxSegmentEntries(pieceSize uint64) uint {
+	n := pieceSize / 2048 / segmentEntrySize
+	if n <= 4 {
+		return 4
+	}
+	return 1 << bits.Len64(n-1)
+}
+
+// segmentIndexOffset is the padded offset of the index in a deal of
+// pieceSize bytes
+func segmentIndexOffset(pieceSize uint64) uint64 {
+	return pieceSize - uint64(maxSegmentEntries(pieceSize))*segmentEntrySize
+}
+
+// segmentEntryBytes is a serialized data segment index entry
+type segmentEntryBytes []byte
+
+func (e segmentEntryBytes) commDs() MerkleNode {
+	var n MerkleNode
+	copy(n[:], e[:merkleNodeSize])
+	return n
+}
+
+// segmentEntry serializes the index entry of sp: its commP, padded offset and
+// padded size followed by a checksum, the sha256 of the whole entry with the
+// checksum still zero, truncated to 126 bits.
+func segmentEntry(sp SubPiece) (segmentEntryBytes, error) {
+	c, err := cid.Parse(sp.PieceCid)
+	if err != nil {
+		return nil, err
+	}
+	commDs, err := commcid.CIDToDataCommitmentV1(c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commp %s: %w", sp.PieceCid, err)
+	}
+	entry := make(segmentEntryBytes, segmentEntrySize)
+	copy(entry, commDs)
+	binary.LittleEndian.PutUint64(entry[32:], sp.Offset)
+	binary.LittleEndian.PutUint64(entry[40:], sp.PieceSize)
+	sum := sha256.Sum256(entry)
+	sum[segmentChecksumSize-1] &= 0x3f
+	copy(entry[segmentEntrySize-segmentChecksumSize:], sum[:segmentChecksumSize])
+	return entry, nil
+}
+
+// unpadFr32 reverses Fr32 padding: every 128 padded bytes are four 254 bit
+// field elements that pack into 127 bytes of data.
+func unpadFr32(padded []byte) []byte {
+	out := make([]byte, len(padded)/128*127)
+	for chunk := 0; chunk < len(padded)/128; chunk++ {
+		in := padded[chunk*128 : (chunk+1)*128]
+		o := out[chunk*127 : (chunk+1)*127]
+		for bit := 0; bit < 127*8; bit++ {
+			node, nodeBit := bit/254, bit%254
+			if in[node*merkleNodeSize+nodeBit/8]>>(nodeBit%8)&1 == 1 {
+				o[bit/8] |= 1 << (bit % 8)
+			}
+		}
+	}
+	return out
+}
+
+// treeLevel is the level of the piece tree holding the commP of a piece of
+// pieceSize bytes, leaves being level 0
+func treeLevel(pieceSize uint64) int {
+	return bits.TrailingZeros64(pieceSize / merkleNodeSize)
+}
+
+// pieceTree is a sparse piece commitment tree: nodes that are not set are
+// the commitments of all-zero subtrees.
+type pieceTree struct {
+	levels []map[uint64]MerkleNode
+	zeros  []MerkleNode
+}
+
+func newPieceTree(pieceSize uint64) *pieceTree {
+	height := treeLevel(pieceSize)
+	t := &pieceTree{
+		levels: make([]map[uint64]MerkleNode, height+1),
+		zeros:  make([]MerkleNode, height+1),
+	}
+	for l := range t.levels {
+		t.levels[l] = make(map[uint64]MerkleNode)
+		if l > 0 {
+			t.zeros[l] = hashNodes(t.zeros[l-1], t.zeros[l-1])
+		}
+	}
+	return t
+}
+
+func (t *pieceTree) set(level int, index uint64, n MerkleNode) {
+	t.levels[level][index] = n
+}
+
+func (t *pieceTree) get(level int, index uint64) MerkleNode {
+	if n, ok := t.levels[level][index]; ok {
+		return n
+	}
+	return t.zeros[level]
+}
+
+// build computes every node above the ones set and returns the root
+func (t *pieceTree) build() MerkleNode {
+	for l := 0; l < len(t.levels)-1; l++ {
+		for index := range t.levels[l] {
+			if _, ok := t.levels[l+1][index/2]; ok {
+				continue
+			}
+			t.levels[l+1][index/2] = hashNodes(t.get(l, index&^1), t.get(l, index|1))
+		}
+	}
+	return t.get(len(t.levels)-1, 0)
+}
+
+func (t *pieceTree) proof(level int, index uint64) ProofData {
+	p := ProofData{Index: index}
+	for l := level; l < len(t.levels)-1; l++ {
+		p.Path = append(p.Path, t.get(l, index^1))
+		index /= 2
+	}
+	return p
+}
+
+// hashNodes is the sha256-trunc254 node hash of piece commitment trees
+func hashNodes(left, right MerkleNode) MerkleNode {
+	h := sha256.New()
+	h.Write(left[:])
+	h.Write(right[:])
+	var n MerkleNode
+	copy(n[:], h.Sum(nil))
+	n[merkleNodeSize-1] &= 0x3f
+	return n
+}
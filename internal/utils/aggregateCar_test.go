@@ -0,0 +1,283 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commputils "github.com/filecoin-project/go-commp-utils/v2"
+	"github.com/filecoin-project/go-commp-utils/v2/zerocomm"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/lotus/storage/sealer/fr32"
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-car"
+	carutil "github.com/ipld/go-car/util"
+	"github.com/multiformats/go-multihash"
+)
+
+// The aggregates are checked against implementations independent of the
+// piece tree of aggregateCar.go: the aggregate commP against the piece
+// aggregation of go-commp-utils, which follows rust-fil-proofs, the Fr32
+// unpadding against the one of lotus and the index entries against vectors
+// computed separately from the FRC-0058 layout.
+
+// writeTestCarFile writes a CAR of one raw block of size random bytes
+func writeTestCarFile(t *testing.T, dir string, size int) string {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.CreateTemp(dir, "*.car")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := car.WriteHeader(&car.CarHeader{Roots: []cid.Cid{c}, Version: 1}, f); err != nil {
+		t.Fatal(err)
+	}
+	if err := carutil.LdWrite(f, c.Bytes(), data); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestSegmentEntry(t *testing.T) {
+	commDs, _ := hex.DecodeString("8437a2114eba63c2eba150a87fe5d132420bdc0a23d3e34f69d687c09d12ce0e")
+	pieceCid, err := commcid.DataCommitmentV1ToCID(commDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := segmentEntry(SubPiece{PieceCid: pieceCid.String(), Offset: 65536, PieceSize: 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// commDs, offset 65536 and size 2048 as little endian uint64, then the
+	// sha256 of those with a zero checksum, truncated to 126 bits
+	expected := "8437a2114eba63c2eba150a87fe5d132420bdc0a23d3e34f69d687c09d12ce0e" +
+		"0000010000000000" + "0008000000000000" +
+		"d9be0cdae53c9023ee615e8def67d105"
+	if got := hex.EncodeToString(entry); got != expected {
+		t.Fatalf("got entry %s, expected %s", got, expected)
+	}
+}
+
+func TestUnpadFr32(t *testing.T) {
+	data := make([]byte, 4*127)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	padded := make([]byte, 4*128)
+	fr32.Pad(data, padded)
+	if !bytes.Equal(unpadFr32(padded), data) {
+		t.Fatal("unpadFr32 does not reverse the fr32 padding of lotus")
+	}
+}
+
+func TestSegmentIndexOffset(t *testing.T) {
+	for _, tc := range []struct {
+		pieceSize uint64
+		entries   uint
+	}{
+		{2048, 4},
+		{1 << 20, 8},
+		{8 << 20, 64},
+		{32 << 30, 262144},
+	} {
+		if got := maxSegmentEntries(tc.pieceSize); got != tc.entries {
+			t.Errorf("maxSegmentEntries(%d): got %d, expected %d", tc.pieceSize, got, tc.entries)
+		}
+		if got := segmentIndexOffset(tc.pieceSize); got != tc.pieceSize-uint64(tc.entries)*segmentEntrySize {
+			t.Errorf("segmentIndexOffset(%d): got %d", tc.pieceSize, got)
+		}
+	}
+}
+
+// verifyProof folds the path of p from node up to the root
+func verifyProof(node MerkleNode, p ProofData) MerkleNode {
+	index := p.Index
+	for _, sibling := range p.Path {
+		if index&1 == 0 {
+			node = hashNodes(node, sibling)
+		} else {
+			node = hashNodes(sibling, node)
+		}
+		index /= 2
+	}
+	return node
+}
+
+// aggregateCommP computes the commP of res from the commPs of its sub-pieces
+// and of its index, filling the gaps with zero pieces
+func aggregateCommP(t *testing.T, res *AggregateResult, index []byte) cid.Cid {
+	type region struct {
+		offset, size uint64
+		pieceCid     cid.Cid
+	}
+	var regions []region
+	for _, sp := range res.SubPieces {
+		c, err := cid.Parse(sp.PieceCid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		regions = append(regions, region{sp.Offset, sp.PieceSize, c})
+	}
+	cp := new(commp.Calc)
+	cp.Write(index)
+	rawIndexCommP, _, err := cp.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexCid, _ := commcid.DataCommitmentV1ToCID(rawIndexCommP)
+	regions = append(regions, region{res.IndexOffset, res.PieceSize - res.IndexOffset, indexCid})
+
+	var pieces []abi.PieceInfo
+	var pos uint64
+	for _, r := range regions {
+		for pos < r.offset {
+			// the largest zero piece aligned at pos that fits the gap
+			size := uint64(1) << bits.TrailingZeros64(pos|1<<63)
+			for pos+size > r.offset {
+				size /= 2
+			}
+			pieces = append(pieces, abi.PieceInfo{Size: abi.PaddedPieceSize(size), PieceCID: zerocomm.ZeroPieceCommitment(abi.PaddedPieceSize(size).Unpadded())})
+			pos += size
+		}
+		pieces = append(pieces, abi.PieceInfo{Size: abi.PaddedPieceSize(r.size), PieceCID: r.pieceCid})
+		pos = r.offset + r.size
+	}
+
+	proofType := abi.RegisteredSealProof_StackedDrg2KiBV1_1
+	if res.PieceSize == 8<<20 {
+		proofType = abi.RegisteredSealProof_StackedDrg8MiBV1_1
+	}
+	c, size, err := commputils.PieceAggregateCommP(proofType, pieces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uint64(size) != res.PieceSize {
+		t.Fatalf("pieces add up to %d bytes, expected %d", size, res.PieceSize)
+	}
+	return c
+}
+
+func TestAggregateCars(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		pieceSize uint64
+		carSizes  []int
+	}{
+		{"2KiB", 2048, []int{100, 300, 60}},
+		{"8MiB", 8 << 20, []int{1 << 20, 3 << 20, 5000, 200}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var carPaths []string
+			for _, size := range tc.carSizes {
+				carPaths = append(carPaths, writeTestCarFile(t, dir, size))
+			}
+			res, err := AggregateCars(dir, tc.pieceSize, carPaths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.PieceSize != tc.pieceSize {
+				t.Fatalf("got piece size %d, expected %d", res.PieceSize, tc.pieceSize)
+			}
+			data, err := os.ReadFile(res.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uint64(len(data)) != res.DataSize {
+				t.Fatalf("aggregate holds %d bytes, expected %d", len(data), res.DataSize)
+			}
+
+			// the index is the Fr32 padded form of the end of the aggregate
+			index := make([]byte, res.PieceSize-res.IndexOffset)
+			fr32.Pad(data[res.IndexOffset/128*127:], index)
+			if expected := aggregateCommP(t, res, data[res.IndexOffset/128*127:]); res.PieceCid != expected.String() {
+				t.Fatalf("got aggregate commp %s, expected %s", res.PieceCid, expected)
+			}
+			pieceCid, _ := cid.Parse(res.PieceCid)
+			rawRoot, _ := commcid.CIDToDataCommitmentV1(pieceCid)
+			var root MerkleNode
+			copy(root[:], rawRoot)
+
+			for i, sp := range res.SubPieces {
+				car, err := os.ReadFile(sp.CarPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				start := sp.Offset / 128 * 127
+				if !bytes.Equal(data[start:start+uint64(len(car))], car) {
+					t.Errorf("%s is not at offset %d of the aggregate", sp.CarPath, sp.Offset)
+				}
+
+				c, _ := cid.Parse(sp.PieceCid)
+				rawCommP, _ := commcid.CIDToDataCommitmentV1(c)
+				var commP MerkleNode
+				copy(commP[:], rawCommP)
+				if verifyProof(commP, sp.Proof.Subtree) != root {
+					t.Errorf("subtree proof of %s does not lead to the aggregate commp", sp.CarPath)
+				}
+
+				entry := index[i*segmentEntrySize : (i+1)*segmentEntrySize]
+				expected, err := segmentEntry(sp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(entry, expected) {
+					t.Errorf("index entry %d is %x, expected %x", i, entry, []byte(expected))
+				}
+				var left, right MerkleNode
+				copy(left[:], entry[:merkleNodeSize])
+				copy(right[:], entry[merkleNodeSize:])
+				if verifyProof(hashNodes(left, right), sp.Proof.Index) != root {
+					t.Errorf("index proof of %s does not lead to the aggregate commp", sp.CarPath)
+				}
+			}
+			// unused entries are zero
+			if !bytes.Equal(index[len(res.SubPieces)*segmentEntrySize:], make([]byte, len(index)-len(res.SubPieces)*segmentEntrySize)) {
+				t.Error("unused index entries are not zero")
+			}
+		})
+	}
+}
+
+func TestAggregateCarsSmallestPieceSize(t *testing.T) {
+	dir := t.TempDir()
+	carPaths := []string{writeTestCarFile(t, dir, 1000), writeTestCarFile(t, dir, 3000)}
+	res, err := AggregateCars(dir, 0, carPaths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4KiB and 2KiB sub-pieces leave room for the 256 byte index in 8KiB
+	if res.PieceSize != 8<<10 {
+		t.Fatalf("got piece size %d, expected %d", res.PieceSize, 8<<10)
+	}
+	if _, err := AggregateCars(dir, 4096, carPaths); err == nil {
+		t.Fatal("expected the sub-pieces not to fit a piece of 4096 bytes")
+	}
+}
+
+func TestAggregateCarsManifest(t *testing.T) {
+	dir := t.TempDir()
+	res, err := AggregateCars(dir, 0, []string{writeTestCarFile(t, dir, 500)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(res.Path) != res.PieceCid+".dat" || filepath.Base(res.ManifestPath) != res.PieceCid+aggregateExt {
+		t.Fatalf("unexpected paths %s and %s", res.Path, res.ManifestPath)
+	}
+	if _, err := os.Stat(res.ManifestPath); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	HostLighthouse = "lighthouse"
+	HostS3         = "s3"
+	HostLocal      = "local"
+	HostWebdav     = "webdav"
+)
+
+// HostedCar is where a storage provider can fetch a hosted CAR from
+type HostedCar struct {
+	URL string
+	// Headers are the key=value HTTP headers to send with the request
+	Headers []string
+	// ProviderHeaders are extra key=value headers for a single provider
+	ProviderHeaders map[string][]string
+	// Local is set when the CAR is served from the local disk and must be kept
+	Local bool
+}
+
+// CarHost makes CAR files reachable over HTTP for online deals
+type CarHost interface {
+	// Host uploads the CAR at carPath once for all providers to fetch. The
+	// CAR may be moved, so callers must not rely on carPath afterwards unless
+	// the returned HostedCar is Local.
+	Host(ctx context.Context, carPath string, providers []string) (*HostedCar, error)
+}
+
+// CarHostFromFlags builds the CarHost selected with --host, configured by
+// the flags (and environment variables) of that backend. It returns nil when
+// no backend is selected.
+func CarHostFromFlags(c *cli.Context) (CarHost, error) {
+	switch c.String("host") {
+	case "":
+		return nil, nil
+	case HostLighthouse:
+		apiKey := c.String("apikey")
+		if apiKey == "" {
+			apiKey = os.Getenv("LIGHTHOUSE_API_KEY")
+			if apiKey == "" {
+				return nil, fmt.Errorf("API key for Lighthouse is required when using --host=%s", HostLighthouse)
+			}
+		}
+		return &LighthouseCarHost{APIKey: apiKey}, nil
+	case HostS3:
+		h := &S3CarHost{
+			Endpoint:  c.String("s3-endpoint"),
+			Region:    c.String("s3-region"),
+			Bucket:    c.String("s3-bucket"),
+			Prefix:    c.String("s3-prefix"),
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			URLExpiry: c.Duration("s3-url-expiry"),
+		}
+		if h.Endpoint == "" || h.Bucket == "" {
+			return nil, fmt.Errorf("--s3-endpoint and --s3-bucket are required when using --host=%s", HostS3)
+		}
+		if h.AccessKey == "" || h.SecretKey == "" {
+			return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set when using --host=%s", HostS3)
+		}
+		if h.URLExpiry <= 0 || h.URLExpiry > 7*24*time.Hour {
+			return nil, fmt.Errorf("--s3-url-expiry must be between 1s and 168h")
+		}
+		return h, nil
+	case HostLocal:
+		h := &LocalCarHost{
+			Dir: c.String("serve-dir"),
+			URL: c.String("serve-url"),
+		}
+		if h.Dir == "" {
+			h.Dir = c.String("out-dir")
+		}
+		if h.URL == "" {
+			return nil, fmt.Errorf("--serve-url is required when using --host=%s", HostLocal)
+		}
+		return h, nil
+	case HostWebdav:
+		h := &WebdavCarHost{
+			URL:       c.String("webdav-url"),
+			PublicURL: c.String("webdav-public-url"),
+			User:      os.Getenv("WEBDAV_USER"),
+			Password:  os.Getenv("WEBDAV_PASSWORD"),
+		}
+		if h.URL == "" {
+			return nil, fmt.Errorf("--webdav-url is required when using --host=%s", HostWebdav)
+		}
+		return h, nil
+	default:
+		return nil, fmt.Errorf("unknown host %q, expected %s, %s, %s or %s", c.String("host"), HostLighthouse, HostS3, HostLocal, HostWebdav)
+	}
+}
+
+// LocalCarHost moves CARs into Dir, served by `serve start` at URL, and
+// mints a token for every provider.
+type LocalCarHost struct {
+	Dir string
+	URL string
+}
+
+func (h *LocalCarHost) Host(ctx context.Context, carPath string, providers []string) (*HostedCar, error) {
+	name := filepath.Base(carPath)
+	if err := os.MkdirAll(h.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := moveFile(carPath, filepath.Join(h.Dir, name)); err != nil {
+		return nil, fmt.Errorf("failed to move car to %s: %w", h.Dir, err)
+	}
+	hosted := &HostedCar{
+		URL:             fmt.Sprintf("%s/%s", strings.TrimSuffix(h.URL, "/"), name),
+		ProviderHeaders: make(map[string][]string),
+		Local:           true,
+	}
+	for _, provider := range providers {
+		token, err := MintServeToken(h.Dir, strings.TrimSuffix(name, ".car"), provider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mint serve token: %w", err)
+		}
+		hosted.ProviderHeaders[provider] = []string{token.AuthHeader()}
+	}
+	return hosted, nil
+}
+
+// moveFile renames src to dst, copying it when they are on different
+// filesystems
+func moveFile(src string, dst string) error {
+	srcAbs, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	dstAbs, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if srcAbs == dstAbs {
+		return nil
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
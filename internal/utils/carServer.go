@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// serveTokenDir is the directory, under the served directory, that holds one
+// JSON file per minted token. Tokens are minted by whichever process prepares
+// the deal and picked up by the running server on the next request.
+const serveTokenDir = ".tokens"
+
+// ServeToken is a bearer token giving one deal access to one served CAR, and
+// the transfer accounting of that deal.
+type ServeToken struct {
+	Token       string    `json:"token"`
+	PieceCid    string    `json:"pieceCid"`
+	Provider    string    `json:"provider,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Requests    uint64    `json:"requests"`
+	BytesServed uint64    `json:"bytesServed"`
+}
+
+// AuthHeader returns the token as a key=value header, the form expected by
+// the http-headers of a deal.
+func (t *ServeToken) AuthHeader() string {
+	return "Authorization=Bearer " + t.Token
+}
+
+// MintServeToken creates a token that lets provider fetch <pieceCid>.car
+// from the server of dir.
+func MintServeToken(dir string, pieceCid string, provider string) (*ServeToken, error) {
+	if _, err := os.Stat(filepath.Join(dir, pieceCid+".car")); err != nil {
+		return nil, fmt.Errorf("car to serve not found: %w", err)
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	t := &ServeToken{
+		Token:     hex.EncodeToString(b),
+		PieceCid:  pieceCid,
+		Provider:  provider,
+		CreatedAt: time.Now(),
+	}
+	if err := os.MkdirAll(filepath.Join(dir, serveTokenDir), 0700); err != nil {
+		return nil, err
+	}
+	if err := writeServeToken(dir, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ListServeTokens returns every token minted for dir, oldest first
+func ListServeTokens(dir string) ([]ServeToken, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, serveTokenDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tokens []ServeToken
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		t, err := readServeToken(dir, strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+func readServeToken(dir string, token string) (*ServeToken, error) {
+	b, err := os.ReadFile(filepath.Join(dir, serveTokenDir, token+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var t ServeToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse token %s: %w", token, err)
+	}
+	return &t, nil
+}
+
+// writeServeToken replaces the token file atomically so that concurrent
+// readers never see a partial write
+func writeServeToken(dir string, t *ServeToken) error {
+	b, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	tokenPath := filepath.Join(dir, serveTokenDir, t.Token+".json")
+	if err := os.WriteFile(tokenPath+".tmp", b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tokenPath+".tmp", tokenPath)
+}
+
+// CarServer serves the <commP>.car files of Dir over HTTP, with Range
+// support, to requests bearing a token minted for that CAR, and records the
+// bytes served to each token.
+type CarServer struct {
+	Dir string
+	// mu serializes the accounting updates of token files
+	mu sync.Mutex
+}
+
+func (s *CarServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	pieceCid := strings.TrimSuffix(name, ".car")
+	if pieceCid == name || pieceCid == "" || strings.ContainsAny(pieceCid, `/\.`) {
+		http.NotFound(w, r)
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !isServeToken(token) {
+		http.Error(w, "missing or malformed bearer token", http.StatusUnauthorized)
+		return
+	}
+	t, err := readServeToken(s.Dir, token)
+	if err != nil || t.PieceCid != pieceCid {
+		http.Error(w, "token is not valid for this car", http.StatusForbidden)
+		return
+	}
+
+	f, err := os.Open(filepath.Join(s.Dir, name))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cw := &countingResponseWriter{ResponseWriter: w}
+	w.Header().Set("Content-Type", "application/vnd.ipld.car")
+	http.ServeContent(cw, r, name, stat.ModTime(), f)
+	logger.Infow("served car", "car", name, "token", token[:8], "range", r.Header.Get("Range"), "bytes", cw.n)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err = readServeToken(s.Dir, token)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	t.Requests++
+	t.BytesServed += cw.n
+	if err := writeServeToken(s.Dir, t); err != nil {
+		logger.Warn(err)
+	}
+}
+
+func isServeToken(token string) bool {
+	b, err := hex.DecodeString(token)
+	return err == nil && len(b) == 32
+}
+
+// countingResponseWriter counts the body bytes written to a response
+type countingResponseWriter struct {
+	http.ResponseWriter
+	n uint64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.n += uint64(n)
+	return n, err
+}
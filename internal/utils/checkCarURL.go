@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/ipld/go-car"
+)
+
+// CheckCarURL makes the request a storage provider starts a CAR transfer
+// with, a single byte Range GET with the same headers, and checks that the
+// CAR at url is reachable and is carSize bytes long. No HEAD request is sent:
+// providers never make one, and presigned URLs, whose signature covers the
+// method, reject it.
+func CheckCarURL(ctx context.Context, url string, headers map[string]string, carSize uint64) error {
+	client := &http.Client{Timeout: time.Minute}
+
+	resp, err := doCarRequest(ctx, client, http.MethodGet, url, headers, "bytes=0-0")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		total, err := strconv.ParseUint(contentRange[strings.LastIndex(contentRange, "/")+1:], 10, 64)
+		if err != nil {
+			return fmt.Errorf("GET %s: invalid Content-Range %q", url, contentRange)
+		}
+		if total != carSize {
+			return fmt.Errorf("GET %s: object size is %d, expected car size %d", url, total, carSize)
+		}
+	case http.StatusOK:
+		logger.Warnw("server ignores Range requests, interrupted transfers will restart from the beginning", "url", url)
+		if resp.ContentLength >= 0 && uint64(resp.ContentLength) != carSize {
+			return fmt.Errorf("GET %s: content length is %d, expected car size %d", url, resp.ContentLength, carSize)
+		}
+	default:
+		return fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return nil
+}
+
+// CheckCarURLCommp downloads the whole CAR at url and checks that it is
+// carSize bytes long and that its commP, padded to pieceSize, is pieceCid.
+func CheckCarURLCommp(ctx context.Context, url string, headers map[string]string, carSize uint64, pieceCid string, pieceSize uint64) error {
+	resp, err := doCarRequest(ctx, http.DefaultClient, http.MethodGet, url, headers, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	cr := &countingReader{r: resp.Body}
+	commCid, _, err := CalcCommp(cr, pieceSize)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	if cr.n != carSize {
+		return fmt.Errorf("GET %s: downloaded %d bytes, expected car size %d", url, cr.n, carSize)
+	}
+	if commCid.String() != pieceCid {
+		return fmt.Errorf("GET %s: commp is %s, expected %s", url, commCid, pieceCid)
+	}
+	return nil
+}
+
+// RemoteCar holds the deal parameters derived from a CAR served over HTTP
+type RemoteCar struct {
+	PayloadCid string
+	PieceCid   string
+	PieceSize  uint64
+	CarSize    uint64
+}
+
+// InspectCarURL streams the CAR at url once, reading the payload CID from its
+// header while computing its size and its commP, padded up to pieceSize when
+// it is non-zero.
+func InspectCarURL(ctx context.Context, url string, headers map[string]string, pieceSize uint64) (*RemoteCar, error) {
+	resp, err := doCarRequest(ctx, http.DefaultClient, http.MethodGet, url, headers, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	cp := new(commp.Calc)
+	cr := &countingReader{r: resp.Body}
+	tee := io.TeeReader(cr, cp)
+	carReader, err := car.NewCarReader(tee)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: failed to read car header: %w", url, err)
+	}
+	if len(carReader.Header.Roots) != 1 {
+		return nil, fmt.Errorf("GET %s: expected exactly one root in car header, found %d", url, len(carReader.Header.Roots))
+	}
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return nil, fmt.Errorf("GET %s: %w", url, err)
+	}
+	if resp.ContentLength >= 0 && uint64(resp.ContentLength) != cr.n {
+		return nil, fmt.Errorf("GET %s: downloaded %d bytes, expected %d", url, cr.n, resp.ContentLength)
+	}
+
+	commCid, paddedSize, err := pieceCommitment(cp, pieceSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute commp: %w", err)
+	}
+	return &RemoteCar{
+		PayloadCid: carReader.Header.Roots[0].String(),
+		PieceCid:   commCid.String(),
+		PieceSize:  paddedSize,
+		CarSize:    cr.n,
+	}, nil
+}
+
+func doCarRequest(ctx context.Context, client *http.Client, method string, url string, headers map[string]string, byteRange string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid car url: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	return resp, nil
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += uint64(n)
+	return n, err
+}
@@ -4,22 +4,22 @@ import (
 	"context"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
 	"github.com/ipfs/boxo/blockservice"
 	bstore "github.com/ipfs/boxo/blockstore"
 	"github.com/ipfs/boxo/ipld/merkledag"
 	dag "github.com/ipfs/boxo/ipld/merkledag"
 	"github.com/ipfs/boxo/ipld/unixfs"
-	"github.com/ipfs/boxo/ipld/unixfs/importer/balanced"
 	ihelper "github.com/ipfs/boxo/ipld/unixfs/importer/helpers"
 	uio "github.com/ipfs/boxo/ipld/unixfs/io"
 	"github.com/ipfs/go-cid"
-	"github.com/ipfs/go-datastore"
-	dss "github.com/ipfs/go-datastore/sync"
+	leveldb "github.com/ipfs/go-ds-leveldb"
 	"github.com/ipfs/go-filestore"
-	chunker "github.com/ipfs/go-ipfs-chunker"
 	offline "github.com/ipfs/go-ipfs-exchange-offline"
 	format "github.com/ipfs/go-ipld-format"
 	ipld "github.com/ipfs/go-ipld-format"
@@ -44,8 +44,13 @@ type FSBuilder struct {
 }
 
 type CidMapValue struct {
-	IsDir bool
-	Cid   string
+	IsDir bool   `json:"isDir"`
+	Cid   string `json:"cid"`
+	// Size is the number of file bytes in the CAR: the slice length for
+	// files and the total of the files beneath for directories.
+	Size  int64 `json:"size"`
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
 }
 
 func getDirKey(dirList []string, i int) (key string) {
@@ -127,8 +132,43 @@ func (fs *fileSlice) Read(p []byte) (n int, err error) {
 	return copy(p, b), io.EOF
 }
 
-func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDir string, output io.Writer) (ipldDag *FsNode, cid string, cidMap map[string]CidMapValue, err error) {
-	batching := dss.MutexWrap(datastore.NewMapDatastore())
+// newBlockDatastore opens a leveldb datastore in a fresh directory under dir
+// (or the system temp dir when dir is empty), so that DAG nodes built while
+// generating a CAR live on disk and memory use does not grow with the dataset.
+// The returned cleanup closes the datastore and removes its directory.
+func newBlockDatastore(dir string) (*leveldb.Datastore, func(), error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return nil, nil, err
+		}
+	}
+	dsDir, err := os.MkdirTemp(dir, "wrappedeal-blocks-")
+	if err != nil {
+		return nil, nil, err
+	}
+	ds, err := leveldb.NewDatastore(dsDir, nil)
+	if err != nil {
+		os.RemoveAll(dsDir)
+		return nil, nil, err
+	}
+	cleanup := func() {
+		if err := ds.Close(); err != nil {
+			logger.Warn(err)
+		}
+		if err := os.RemoveAll(dsDir); err != nil {
+			logger.Warn(err)
+		}
+	}
+	return ds, cleanup, nil
+}
+
+func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDir string, dagParams types.DagParams, output io.Writer) (ipldDag *FsNode, cid string, cidMap map[string]CidMapValue, err error) {
+	batching, cleanup, err := newBlockDatastore(tmpDir)
+	if err != nil {
+		logger.Warn(err)
+		return
+	}
+	defer cleanup()
 	bs1 := bstore.NewBlockstore(batching)
 	absParentPath, err := filepath.Abs(parentPath)
 	cidMap = make(map[string]CidMapValue)
@@ -147,7 +187,7 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 	fm.AllowFiles = true
 	bs2 := filestore.NewFilestore(bs1, fm)
 	dagServ := merkledag.NewDAGService(blockservice.New(bs2, offline.Exchange(bs2)))
-	cidBuilder, err := merkledag.PrefixForCidVersion(1)
+	cidBuilder, err := merkledag.PrefixForCidVersion(dagParams.CidVersion)
 	if err != nil {
 		logger.Warn(err)
 		return
@@ -164,6 +204,7 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 		if item.End == 0 {
 			item.End = item.Size
 		}
+		slice := CidMapValue{Size: item.End - item.Start, Start: item.Start, End: item.End}
 		var node ipld.Node
 		var path string
 		path, err = filepath.Rel(filepath.Clean(parentPath), filepath.Clean(item.Path))
@@ -202,9 +243,10 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 			item.End = item.Size
 			item.Start = 0
 		}
-		node, err = BuildFileNode(ctx, item, dagServ, cidBuilder)
+		node, err = BuildFileNode(ctx, item, dagServ, cidBuilder, dagParams)
 		dagServ.Add(ctx, node)
-		cidMap[path] = CidMapValue{false, node.Cid().String()}
+		slice.Cid = node.Cid().String()
+		cidMap[path] = slice
 		if err != nil {
 			logger.Warn(err)
 			return
@@ -243,7 +285,7 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 					return nil, "", nil, err
 				}
 				dagServ.Add(ctx, n)
-				cidMap[strings.Join(previous[1:], "/")] = CidMapValue{true, n.Cid().String()}
+				cidMap[strings.Join(previous[1:], "/")] = CidMapValue{IsDir: true, Cid: n.Cid().String()}
 				err = (*dirNode).AddChild(ctx, lastName, n)
 				if err != nil {
 					return nil, "", nil, err
@@ -284,7 +326,7 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 				return nil, "", nil, err
 			}
 			dagServ.Add(ctx, n)
-			cidMap[strings.Join(previous[1:], "/")] = CidMapValue{true, n.Cid().String()}
+			cidMap[strings.Join(previous[1:], "/")] = CidMapValue{IsDir: true, Cid: n.Cid().String()}
 			err = (*dirNode).AddChild(ctx, lastName, n)
 			if err != nil {
 				return nil, "", nil, err
@@ -300,7 +342,8 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 	}
 	rootIpldNode, _ := rootNode.GetNode()
 	dagServ.Add(ctx, rootIpldNode)
-	cidMap[""] = CidMapValue{true, rootIpldNode.Cid().String()}
+	cidMap[""] = CidMapValue{IsDir: true, Cid: rootIpldNode.Cid().String()}
+	sumDirSizes(cidMap)
 	selector := allSelector()
 	sc := car.NewSelectiveCar(ctx, bs2, []car.Dag{{Root: rootIpldNode.Cid(), Selector: selector}})
 	err = sc.Write(output)
@@ -319,13 +362,35 @@ func GenerateCar(ctx context.Context, fileList []Finfo, parentPath string, tmpDi
 	return
 }
 
+// sumDirSizes sets the Size of every directory in cidMap to the total size
+// of the files beneath it.
+func sumDirSizes(cidMap map[string]CidMapValue) {
+	for p, v := range cidMap {
+		if v.IsDir {
+			continue
+		}
+		for dir := p; dir != ""; {
+			dir = path.Dir(dir)
+			if dir == "." {
+				dir = ""
+			}
+			d, ok := cidMap[dir]
+			if !ok {
+				continue
+			}
+			d.Size += v.Size
+			cidMap[dir] = d
+		}
+	}
+}
+
 func allSelector() ipldprime.Node {
 	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
 	return ssb.ExploreRecursive(selector.RecursionLimitNone(),
 		ssb.ExploreAll(ssb.ExploreRecursiveEdge())).
 		Node()
 }
-func BuildFileNode(ctx context.Context, item Finfo, bufDs ipld.DAGService, cidBuilder cid.Builder) (node ipld.Node, err error) {
+func BuildFileNode(ctx context.Context, item Finfo, bufDs ipld.DAGService, cidBuilder cid.Builder, dagParams types.DagParams) (node ipld.Node, err error) {
 	f, err := os.Open(item.Path)
 	if err != nil {
 		logger.Warn(err)
@@ -347,20 +412,29 @@ func BuildFileNode(ctx context.Context, item Finfo, bufDs ipld.DAGService, cidBu
 		return
 	}
 
+	// Filestore references record offsets from the start of the reader, so
+	// only whole files can be referenced in place. Slices are stored in the
+	// (disk-backed) blockstore instead, as are non-raw leaves which embed
+	// their data in a UnixFS node.
 	params := ihelper.DagBuilderParams{
-		Maxlinks:   UnixfsLinksPerLevel,
-		RawLeaves:  true,
+		Maxlinks:   dagParams.MaxLinks,
+		RawLeaves:  dagParams.RawLeaves,
 		CidBuilder: cidBuilder,
 		Dagserv:    bufDs,
-		NoCopy:     true,
+		NoCopy:     dagParams.RawLeaves && item.Start == 0 && item.End == item.Size,
+	}
+	spl, err := newSplitter(r, dagParams)
+	if err != nil {
+		logger.Warn(err)
+		return
 	}
-	db, err := params.New(chunker.NewSizeSplitter(r, int64(UnixfsChunkSize)))
+	db, err := params.New(spl)
 	// db.SetOffset(uint64(item.Start))
 	if err != nil {
 		logger.Warn(err)
 		return
 	}
-	node, err = balanced.Layout(db)
+	node, err = layoutDag(db, dagParams)
 	if err != nil {
 		logger.Warn(err)
 		return
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/ipfs/go-cid"
+)
+
+// CarCommp computes the piece CID and padded piece size of the CAR file at
+// carPath. When pieceSize is non-zero the commitment is padded up to it.
+func CarCommp(carPath string, pieceSize uint64) (cid.Cid, uint64, error) {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return cid.Undef, 0, err
+	}
+	defer f.Close()
+
+	return CalcCommp(f, pieceSize)
+}
+
+// CalcCommp streams r through the commP hasher and returns the resulting
+// piece CID and padded piece size, padded up to pieceSize when it is non-zero.
+func CalcCommp(r io.Reader, pieceSize uint64) (cid.Cid, uint64, error) {
+	cp := new(commp.Calc)
+	if _, err := io.Copy(cp, bufio.NewReaderSize(r, BufSize)); err != nil {
+		return cid.Undef, 0, fmt.Errorf("failed to read data: %w", err)
+	}
+	return pieceCommitment(cp, pieceSize)
+}
+
+// pieceCommitment returns the piece CID and padded piece size of the data
+// written to cp, padded up to pieceSize when it is non-zero.
+func pieceCommitment(cp *commp.Calc, pieceSize uint64) (cid.Cid, uint64, error) {
+	rawCommP, paddedSize, err := cp.Digest()
+	if err != nil {
+		return cid.Undef, 0, err
+	}
+	return padPieceCommitment(rawCommP, paddedSize, pieceSize)
+}
+
+// padPieceCommitment returns the piece CID of rawCommP, the commP of a piece
+// of paddedSize bytes, padded up to pieceSize when it is non-zero.
+func padPieceCommitment(rawCommP []byte, paddedSize uint64, pieceSize uint64) (cid.Cid, uint64, error) {
+	var err error
+	if pieceSize > 0 {
+		rawCommP, err = commp.PadCommP(
+			rawCommP,
+			paddedSize,
+			pieceSize,
+		)
+		if err != nil {
+			return cid.Undef, 0, err
+		}
+		paddedSize = pieceSize
+	}
+	commCid, err := commcid.DataCommitmentV1ToCID(rawCommP)
+	if err != nil {
+		return cid.Undef, 0, err
+	}
+	return commCid, paddedSize, nil
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+
+	"github.com/ipfs/boxo/ipld/unixfs/importer/balanced"
+	ihelper "github.com/ipfs/boxo/ipld/unixfs/importer/helpers"
+	"github.com/ipfs/boxo/ipld/unixfs/importer/trickle"
+	chunker "github.com/ipfs/go-ipfs-chunker"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	LayoutBalanced = "balanced"
+	LayoutTrickle  = "trickle"
+)
+
+// DefaultDagParams is the DAG layout CARs have always been generated with:
+// 1MiB fixed-size chunks, 1024 links per level, balanced, CIDv1, raw leaves.
+var DefaultDagParams = types.DagParams{
+	Chunker:    fmt.Sprintf("size-%d", UnixfsChunkSize),
+	MaxLinks:   UnixfsLinksPerLevel,
+	Layout:     LayoutBalanced,
+	CidVersion: 1,
+	RawLeaves:  true,
+}
+
+// KuboDagParams matches the defaults of ipfs add in kubo, so that payload
+// CIDs line up with data added through an IPFS node.
+var KuboDagParams = types.DagParams{
+	Chunker:    "size-262144",
+	MaxLinks:   ihelper.DefaultLinksPerBlock,
+	Layout:     LayoutBalanced,
+	CidVersion: 0,
+	RawLeaves:  false,
+}
+
+// ValidateDagParams checks that p describes a DAG layout that can be built.
+func ValidateDagParams(p types.DagParams) error {
+	if _, err := chunker.FromString(bytes.NewReader(nil), p.Chunker); err != nil {
+		return fmt.Errorf("invalid chunker %q: %w", p.Chunker, err)
+	}
+	if p.MaxLinks < 2 {
+		return fmt.Errorf("links per level must be at least 2, got %d", p.MaxLinks)
+	}
+	if p.Layout != LayoutBalanced && p.Layout != LayoutTrickle {
+		return fmt.Errorf("unknown layout %q, expected %s or %s", p.Layout, LayoutBalanced, LayoutTrickle)
+	}
+	if p.CidVersion != 0 && p.CidVersion != 1 {
+		return fmt.Errorf("unknown cid version %d, expected 0 or 1", p.CidVersion)
+	}
+	return nil
+}
+
+// DagParamsFromFlags builds the DAG params from the chunker, max-links,
+// layout, cid-version, raw-leaves and kubo-defaults flags of cctx.
+func DagParamsFromFlags(cctx *cli.Context) (types.DagParams, error) {
+	p := DefaultDagParams
+	if cctx.Bool("kubo-defaults") {
+		p = KuboDagParams
+	}
+	if cctx.IsSet("chunker") {
+		p.Chunker = cctx.String("chunker")
+	}
+	if cctx.IsSet("max-links") {
+		p.MaxLinks = cctx.Int("max-links")
+	}
+	if cctx.IsSet("layout") {
+		p.Layout = cctx.String("layout")
+	}
+	if cctx.IsSet("cid-version") {
+		p.CidVersion = cctx.Int("cid-version")
+		// like ipfs add, CIDv1 implies raw leaves unless told otherwise
+		p.RawLeaves = p.CidVersion > 0
+	}
+	if cctx.IsSet("raw-leaves") {
+		p.RawLeaves = cctx.Bool("raw-leaves")
+	}
+	if err := ValidateDagParams(p); err != nil {
+		return types.DagParams{}, err
+	}
+	return p, nil
+}
+
+// newSplitter returns the chunker p.Chunker describes over r.
+func newSplitter(r io.Reader, p types.DagParams) (chunker.Splitter, error) {
+	return chunker.FromString(r, p.Chunker)
+}
+
+// layoutDag builds the file DAG from db with the layout p.Layout describes.
+func layoutDag(db *ihelper.DagBuilderHelper, p types.DagParams) (ipld.Node, error) {
+	if p.Layout == LayoutTrickle {
+		return trickle.Layout(db)
+	}
+	return balanced.Layout(db)
+}
+
+// minChunkSize returns the smallest chunk (other than the last one of a
+// file) the chunker described by p can cut, used to estimate CAR overheads.
+func minChunkSize(p types.DagParams) int64 {
+	parts := strings.Split(p.Chunker, "-")
+	// labels are allowed as in rabin-min:16-avg:32-max:64
+	num := func(s string) int64 {
+		n, _ := strconv.ParseInt(s[strings.LastIndex(s, ":")+1:], 10, 64)
+		return n
+	}
+	switch {
+	case parts[0] == "size" && len(parts) == 2:
+		return num(parts[1])
+	case parts[0] == "rabin" && len(parts) == 4:
+		return num(parts[1])
+	case parts[0] == "rabin" && len(parts) == 2:
+		return num(parts[1]) / 3
+	case parts[0] == "rabin":
+		return chunker.DefaultBlockSize / 3
+	case parts[0] == "buzhash":
+		return 128 << 10
+	default:
+		return chunker.DefaultBlockSize
+	}
+}
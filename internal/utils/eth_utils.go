@@ -3,7 +3,6 @@ package utils
 import (
 	"context"
 	"fmt"
-	"log"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
@@ -21,20 +20,11 @@ func EstimateGas(client *ethclient.Client, from common.Address, to common.Addres
 	}
 	gasLimit, err := client.EstimateGas(context.Background(), msg)
 	if err != nil {
-		return 0, fmt.Errorf("failed to estimate gas: %v", err)
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
 	}
 	return gasLimit, nil
 }
 
-// Utility function to get nonce
-func GetNonce(client *ethclient.Client, from common.Address) uint64 {
-	nonce, err := client.PendingNonceAt(context.Background(), from)
-	if err != nil {
-		log.Fatalf("Failed to get nonce: %v", err)
-	}
-	return nonce
-}
-
 // convertPrice converts price per TB per month to price per byte per epoch (30 seconds)
 func ConvertPrice(pricePerTbMonth float64) *big.Int {
 	// 1 TB = 1e12 bytes
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ipfs/boxo/blockservice"
+	"github.com/ipfs/boxo/ipld/merkledag"
+	uio "github.com/ipfs/boxo/ipld/unixfs/io"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	ipld "github.com/ipfs/go-ipld-format"
+	carbs "github.com/ipld/go-car/v2/blockstore"
+)
+
+// ExtractResult counts what ExtractCar wrote to disk
+type ExtractResult struct {
+	Files int   `json:"files"`
+	Dirs  int   `json:"dirs"`
+	Bytes int64 `json:"bytes"`
+}
+
+// CarExtractor restores the UnixFS trees of CARs onto disk. Extracting all
+// the CARs of a split dataset with the same CarExtractor reassembles the files
+// sliced across them, whatever the order the CARs are extracted in.
+type CarExtractor struct {
+	// created holds the outputs already created and truncated
+	created map[string]bool
+}
+
+// NewCarExtractor returns a CarExtractor that has not written any file yet
+func NewCarExtractor() *CarExtractor {
+	return &CarExtractor{created: map[string]bool{}}
+}
+
+// ExtractCar restores the CAR at carPath with a new CarExtractor, see
+// CarExtractor.Extract.
+func ExtractCar(ctx context.Context, carPath string, out string, subPath string) (*ExtractResult, error) {
+	return NewCarExtractor().Extract(ctx, carPath, out, subPath)
+}
+
+// Extract restores the UnixFS tree under the root of the CAR at carPath, or
+// only the entry at subPath when it is not empty, onto out. When a manifest
+// sits next to the CAR, every file is written at the Start offset of its
+// slice so that extracting all the CARs of a split dataset into the same out
+// reassembles the original files. A file is truncated the first time e
+// writes to it only, so the slices may come in any order.
+//
+// A single file, either picked with subPath or the only content of a CAR
+// generated from one file (which holds it under the name "."), is written to
+// out itself, or into out when out is an existing directory.
+func (e *CarExtractor) Extract(ctx context.Context, carPath string, out string, subPath string) (*ExtractResult, error) {
+	var slices map[string]CidMapValue
+	manifest, err := ReadManifest(ManifestPath(carPath))
+	if err == nil {
+		slices = manifest.Files
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	bs, err := carbs.OpenReadOnly(carPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open car blockstore: %w", err)
+	}
+	defer bs.Close()
+
+	roots, err := bs.Roots()
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) != 1 {
+		return nil, fmt.Errorf("expected exactly one root in car header, found %d", len(roots))
+	}
+
+	dagServ := merkledag.NewDAGService(blockservice.New(bs, offline.Exchange(bs)))
+	nd, err := dagServ.Get(ctx, roots[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load root %s: %w", roots[0], err)
+	}
+	name := ""
+	subPath = strings.Trim(path.Clean("/"+subPath), "/")
+	for _, part := range strings.Split(subPath, "/") {
+		if part == "" {
+			continue
+		}
+		dir, err := uio.NewDirectoryFromNode(dagServ, nd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %s is not a directory", subPath, name)
+		}
+		nd, err = dir.Find(ctx, part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", subPath, err)
+		}
+		name = path.Join(name, part)
+	}
+
+	if stat, err := os.Stat(out); err == nil && stat.IsDir() && isSingleFile(ctx, dagServ, nd, name) {
+		if name == "" || name == "." {
+			out = filepath.Join(out, nd.Cid().String())
+		} else {
+			out = filepath.Join(out, path.Base(name))
+		}
+	}
+
+	res := &ExtractResult{}
+	if err := e.extractNode(ctx, dagServ, nd, name, out, slices, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// isSingleFile reports whether nd, found at name, extracts to a single file
+// rather than a directory tree: a file entry, or the root directory of a CAR
+// generated from a single file.
+func isSingleFile(ctx context.Context, dagServ ipld.DAGService, nd ipld.Node, name string) bool {
+	dir, err := uio.NewDirectoryFromNode(dagServ, nd)
+	if err != nil {
+		return true
+	}
+	if name != "" {
+		return false
+	}
+	links, err := dir.Links(ctx)
+	return err == nil && len(links) == 1 && links[0].Name == "."
+}
+
+// extractNode writes nd, found at name in the CAR, to out and descends into
+// its entries when it is a directory.
+func (e *CarExtractor) extractNode(ctx context.Context, dagServ ipld.DAGService, nd ipld.Node, name string, out string, slices map[string]CidMapValue, res *ExtractResult) error {
+	dir, err := uio.NewDirectoryFromNode(dagServ, nd)
+	if errors.Is(err, uio.ErrNotADir) {
+		return e.extractFile(ctx, dagServ, nd, name, out, slices[name].Start, res)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", name, err)
+	}
+
+	links, err := dir.Links(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list directory %s: %w", name, err)
+	}
+	if name == "" && len(links) == 1 && links[0].Name == "." {
+		child, err := dagServ.Get(ctx, links[0].Cid)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", links[0].Cid, err)
+		}
+		return e.extractFile(ctx, dagServ, child, ".", out, slices["."].Start, res)
+	}
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	res.Dirs++
+	for _, lnk := range links {
+		if lnk.Name == "" || lnk.Name == "." || lnk.Name == ".." || strings.ContainsAny(lnk.Name, `/\`) {
+			return fmt.Errorf("refusing to extract unsafe entry name %q in %s", lnk.Name, name)
+		}
+		child, err := dagServ.Get(ctx, lnk.Cid)
+		if err != nil {
+			return fmt.Errorf("failed to load %s (%s): %w", path.Join(name, lnk.Name), lnk.Cid, err)
+		}
+		err = e.extractNode(ctx, dagServ, child, path.Join(name, lnk.Name), filepath.Join(out, lnk.Name), slices, res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractFile writes the content of the UnixFS file nd to out, starting at
+// offset start. out is truncated the first time e writes to it, so that no
+// stale bytes of an older, longer file are left behind, and the later slices
+// are written at their offset next to the ones already there.
+func (e *CarExtractor) extractFile(ctx context.Context, dagServ ipld.DAGService, nd ipld.Node, name string, out string, start int64, res *ExtractResult) error {
+	dr, err := uio.NewDagReader(ctx, nd, dagServ)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", name, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return err
+	}
+	flags := os.O_WRONLY | os.O_CREATE
+	if !e.created[out] {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(out, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	e.created[out] = true
+
+	n, err := io.Copy(io.NewOffsetWriter(f, start), dr)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+	res.Files++
+	res.Bytes += n
+	return f.Close()
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExtractSplitCarsAnyOrder extracts the CARs of a file split across
+// several of them in reverse order, over a stale and longer file
+func TestExtractSplitCarsAnyOrder(t *testing.T) {
+	inDir, carDir, outDir := t.TempDir(), t.TempDir(), t.TempDir()
+	data := make([]byte, 20000)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(inDir, "data.bin"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &CarParams{Input: inDir, Parent: inDir, OutDir: carDir, TmpDir: t.TempDir(), Single: true, SplitSize: 8 << 10}
+	results, err := c.GenerateSplitCarUtil()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) < 2 {
+		t.Fatalf("got %d CARs, expected the file to be split", len(results))
+	}
+
+	out := filepath.Join(outDir, "data.bin")
+	if err := os.WriteFile(out, make([]byte, 2*len(data)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewCarExtractor()
+	for i := len(results) - 1; i >= 0; i-- {
+		carPath := filepath.Join(carDir, results[i].PieceCid+".car")
+		if _, err := e.Extract(context.Background(), carPath, outDir, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("extracted %d bytes do not match the %d bytes of the input", len(got), len(data))
+	}
+}
@@ -12,10 +12,10 @@ import (
 
 	"github.com/eastore-project/fil-deal-wrapper/internal/types"
 
-	commcid "github.com/filecoin-project/go-fil-commcid"
 	commp "github.com/filecoin-project/go-fil-commp-hashhash"
 	"github.com/google/uuid"
 )
+
 type Result struct {
 	Ipld      *FsNode
 	DataCid   string
@@ -23,18 +23,44 @@ type Result struct {
 	PieceSize uint64
 	CidMap    map[string]CidMapValue
 	CarSize   uint64
+	// ManifestPath is where the manifest of the CAR was written
+	ManifestPath string
+	// Dag is the UnixFS DAG layout the CAR was built with
+	Dag types.DagParams
 }
 type CarParams types.CarParams
 
 const BufSize = (4 << 20) / 128 * 127
 
 func (c *CarParams) GenerateCarUtil() (Result, error) {
-	ctx := context.Background()
+	if err := c.setDagParams(); err != nil {
+		return Result{}, err
+	}
+	input, err := c.inputFiles()
+	if err != nil {
+		return Result{}, err
+	}
+	return c.generateCar(context.Background(), input)
+}
+
+// setDagParams fills in DefaultDagParams when c.Dag is left unset and checks
+// the DAG params otherwise.
+func (c *CarParams) setDagParams() error {
+	if c.Dag == (types.DagParams{}) {
+		c.Dag = DefaultDagParams
+	}
+	return ValidateDagParams(c.Dag)
+}
+
+// inputFiles lists the files (or file slices) that make up c.Input: every file
+// under the path when c.Single is set, otherwise the JSON encoded []Finfo read
+// from the file at c.Input or from stdin when c.Input is "-".
+func (c *CarParams) inputFiles() ([]Finfo, error) {
 	var input []Finfo
 	if c.Single {
 		stat, err := os.Stat(c.Input)
 		if err != nil {
-			return Result{}, err
+			return nil, err
 		}
 		if stat.IsDir() {
 			err := filepath.Walk(c.Input, func(path string, info os.FileInfo, err error) error {
@@ -53,7 +79,7 @@ func (c *CarParams) GenerateCarUtil() (Result, error) {
 				return nil
 			})
 			if err != nil {
-				return Result{}, err
+				return nil, err
 			}
 		} else {
 			input = append(input, Finfo{
@@ -70,22 +96,26 @@ func (c *CarParams) GenerateCarUtil() (Result, error) {
 			buf := new(bytes.Buffer)
 			_, err := buf.ReadFrom(reader)
 			if err != nil {
-				return Result{}, err
+				return nil, err
 			}
 			inputBytes = buf.Bytes()
 		} else {
 			bytes, err := os.ReadFile(c.Input)
 			if err != nil {
-				return Result{}, err
+				return nil, err
 			}
 			inputBytes = bytes
 		}
 		err := json.Unmarshal(inputBytes, &input)
 		if err != nil {
-			return Result{}, err
+			return nil, err
 		}
 	}
+	return input, nil
+}
 
+// generateCar packs input into a single CAR in c.OutDir named after its commP.
+func (c *CarParams) generateCar(ctx context.Context, input []Finfo) (Result, error) {
 	outFilename := uuid.New().String() + ".car"
 	outPath := path.Join(c.OutDir, outFilename)
 	carF, err := os.Create(outPath)
@@ -94,7 +124,7 @@ func (c *CarParams) GenerateCarUtil() (Result, error) {
 	}
 	cp := new(commp.Calc)
 	writer := bufio.NewWriterSize(io.MultiWriter(carF, cp), BufSize)
-	ipld, cid, cidMap, err := GenerateCar(ctx, input, c.Parent, c.TmpDir, writer)
+	ipld, cid, cidMap, err := GenerateCar(ctx, input, c.Parent, c.TmpDir, c.Dag, writer)
 	if err != nil {
 		return Result{}, err
 	}
@@ -106,22 +136,11 @@ func (c *CarParams) GenerateCarUtil() (Result, error) {
 	if err != nil {
 		return Result{}, err
 	}
-	rawCommP, pieceSize, err := cp.Digest()
+	commCid, pieceSize, err := pieceCommitment(cp, c.PieceSize)
 	if err != nil {
 		return Result{}, err
 	}
-	if c.PieceSize > 0 {
-		rawCommP, err = commp.PadCommP(
-			rawCommP,
-			pieceSize,
-			c.PieceSize,
-		)
-		if err != nil {
-			return Result{}, err
-		}
-		pieceSize = c.PieceSize
-	}
-	commCid, err := commcid.DataCommitmentV1ToCID(rawCommP)
+	carStat, err := os.Stat(outPath)
 	if err != nil {
 		return Result{}, err
 	}
@@ -135,6 +154,12 @@ func (c *CarParams) GenerateCarUtil() (Result, error) {
 		PieceCid:  commCid.String(),
 		PieceSize: pieceSize,
 		CidMap:    cidMap,
+		CarSize:   uint64(carStat.Size()),
+		Dag:       c.Dag,
+	}
+	result.ManifestPath, err = WriteManifest(c.OutDir, result)
+	if err != nil {
+		return Result{}, err
 	}
 	return result, nil
 }
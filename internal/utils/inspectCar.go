@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/ipfs/boxo/blockservice"
+	"github.com/ipfs/boxo/ipld/merkledag"
+	uio "github.com/ipfs/boxo/ipld/unixfs/io"
+	"github.com/ipfs/go-cid"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipld/go-car"
+	carbs "github.com/ipld/go-car/v2/blockstore"
+)
+
+// CarInfo describes the content of a CAR file
+type CarInfo struct {
+	Roots      []string               `json:"roots"`
+	BlockCount uint64                 `json:"blockCount"`
+	CarSize    uint64                 `json:"carSize"`
+	CidMap     map[string]CidMapValue `json:"cidMap"`
+}
+
+// InspectCar reads the CAR at carPath and returns its roots, the number of
+// blocks it holds and the path to CID map of the UnixFS DAG under its first root.
+func InspectCar(ctx context.Context, carPath string) (*CarInfo, error) {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	cr, err := car.NewCarReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read car header: %w", err)
+	}
+	info := &CarInfo{
+		CarSize: uint64(stat.Size()),
+		CidMap:  make(map[string]CidMapValue),
+	}
+	for _, root := range cr.Header.Roots {
+		info.Roots = append(info.Roots, root.String())
+	}
+	for {
+		_, err := cr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block %d: %w", info.BlockCount, err)
+		}
+		info.BlockCount++
+	}
+
+	if len(cr.Header.Roots) == 0 {
+		return info, nil
+	}
+
+	bs, err := carbs.OpenReadOnly(carPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open car blockstore: %w", err)
+	}
+	defer bs.Close()
+
+	dagServ := merkledag.NewDAGService(blockservice.New(bs, offline.Exchange(bs)))
+	err = walkUnixfs(ctx, dagServ, cr.Header.Roots[0], "", info.CidMap)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// walkUnixfs records c under name in cidMap and, when c is a UnixFS
+// directory, descends into its entries.
+func walkUnixfs(ctx context.Context, dagServ ipld.DAGService, c cid.Cid, name string, cidMap map[string]CidMapValue) error {
+	nd, err := dagServ.Get(ctx, c)
+	if err != nil {
+		return fmt.Errorf("failed to load %s (%s): %w", name, c, err)
+	}
+	dir, err := uio.NewDirectoryFromNode(dagServ, nd)
+	if errors.Is(err, uio.ErrNotADir) {
+		dr, err := uio.NewDagReader(ctx, nd, dagServ)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", name, err)
+		}
+		size := int64(dr.Size())
+		cidMap[name] = CidMapValue{Cid: c.String(), Size: size, End: size}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", name, err)
+	}
+	dirSize := int64(0)
+	err = dir.ForEachLink(ctx, func(lnk *ipld.Link) error {
+		child := path.Join(name, lnk.Name)
+		if err := walkUnixfs(ctx, dagServ, lnk.Cid, child, cidMap); err != nil {
+			return err
+		}
+		dirSize += cidMap[child].Size
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	cidMap[name] = CidMapValue{IsDir: true, Cid: c.String(), Size: dirSize}
+	return nil
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eastore-project/fil-deal-wrapper/internal/types"
+)
+
+const manifestExt = ".manifest.json"
+
+// Manifest records what a generated CAR holds so that files can later be
+// traced back to the piece (and deal) that stores them.
+type Manifest struct {
+	PayloadCid string                 `json:"payloadCid"`
+	PieceCid   string                 `json:"pieceCid"`
+	PieceSize  uint64                 `json:"pieceSize"`
+	CarSize    uint64                 `json:"carSize"`
+	Dag        types.DagParams        `json:"dag"`
+	Files      map[string]CidMapValue `json:"files"`
+	Ipld       *FsNode                `json:"ipld"`
+}
+
+// ManifestPath returns the path of the manifest written next to carPath.
+func ManifestPath(carPath string) string {
+	return strings.TrimSuffix(carPath, ".car") + manifestExt
+}
+
+// WriteManifest writes the manifest of result next to the CAR in outDir.
+func WriteManifest(outDir string, result Result) (string, error) {
+	manifest := Manifest{
+		PayloadCid: result.DataCid,
+		PieceCid:   result.PieceCid,
+		PieceSize:  result.PieceSize,
+		CarSize:    result.CarSize,
+		Dag:        result.Dag,
+		Files:      result.CidMap,
+		Ipld:       result.Ipld,
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	manifestPath := ManifestPath(filepath.Join(outDir, result.PieceCid+".car"))
+	if err := os.WriteFile(manifestPath, manifestBytes, 0644); err != nil {
+		return "", fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return manifestPath, nil
+}
+
+// ReadManifest reads a manifest written by WriteManifest.
+func ReadManifest(manifestPath string) (*Manifest, error) {
+	manifestBytes, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", manifestPath, err)
+	}
+	return &manifest, nil
+}
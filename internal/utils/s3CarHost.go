@@ -0,0 +1,343 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+const (
+	// s3DefaultPartSize is the size of the parts CARs are uploaded in
+	s3DefaultPartSize = 64 << 20
+	// s3MinPartSize is the smallest part S3 accepts, but for the last one
+	s3MinPartSize = 5 << 20
+	// s3MaxParts is the most parts an S3 multipart upload may have
+	s3MaxParts = 10000
+)
+
+// S3CarHost uploads CARs to an S3-compatible bucket (AWS, MinIO, ...) with
+// path-style multipart uploads signed with AWS Signature Version 4, and hands
+// the storage provider a presigned GET URL valid for URLExpiry.
+type S3CarHost struct {
+	// Endpoint is the base URL of the service, e.g. http://localhost:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+	URLExpiry time.Duration
+	// PartSize is the size of the uploaded parts, 64 MiB when 0. It is
+	// raised as needed for the CAR to fit in 10000 parts.
+	PartSize int64
+}
+
+// s3CompletedPart is a part listed in a CompleteMultipartUpload request
+type s3CompletedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+func (h *S3CarHost) Host(ctx context.Context, carPath string, providers []string) (*HostedCar, error) {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL, err := h.objectURL(filepath.Base(carPath))
+	if err != nil {
+		return nil, err
+	}
+	uploadID, err := h.createMultipartUpload(ctx, objectURL)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := h.uploadParts(ctx, objectURL, uploadID, f, stat.Size())
+	if err == nil {
+		err = h.completeMultipartUpload(ctx, objectURL, uploadID, parts)
+	}
+	if err != nil {
+		// do not leave the uploaded parts behind, they are billed
+		if abortErr := h.abortMultipartUpload(context.Background(), objectURL, uploadID); abortErr != nil {
+			return nil, fmt.Errorf("%w (and failed to abort the upload: %v)", err, abortErr)
+		}
+		return nil, err
+	}
+
+	return &HostedCar{URL: h.presignGet(objectURL, time.Now().UTC())}, nil
+}
+
+// partSize returns the size of the parts of an upload of size bytes
+func (h *S3CarHost) partSize(size int64) int64 {
+	partSize := h.PartSize
+	if partSize <= 0 {
+		partSize = s3DefaultPartSize
+	}
+	if partSize < s3MinPartSize {
+		partSize = s3MinPartSize
+	}
+	if min := (size + s3MaxParts - 1) / s3MaxParts; partSize < min {
+		// whole MiBs, like the parts of other clients
+		partSize = (min + 1<<20 - 1) &^ (1<<20 - 1)
+	}
+	return partSize
+}
+
+// createMultipartUpload starts a multipart upload to objectURL and returns
+// its upload ID
+func (h *S3CarHost) createMultipartUpload(ctx context.Context, objectURL *url.URL) (string, error) {
+	req, err := h.newRequest(ctx, http.MethodPost, objectURL, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/vnd.ipld.car")
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if _, err := h.do(req, "start upload", &result); err != nil {
+		return "", err
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("s3 returned no upload id")
+	}
+	return result.UploadID, nil
+}
+
+// uploadParts uploads the size bytes of r as the parts of the upload uploadID
+func (h *S3CarHost) uploadParts(ctx context.Context, objectURL *url.URL, uploadID string, r io.ReaderAt, size int64) ([]s3CompletedPart, error) {
+	partSize := h.partSize(size)
+	var parts []s3CompletedPart
+	for offset, number := int64(0), 1; offset < size || number == 1; offset, number = offset+partSize, number+1 {
+		length := partSize
+		if offset+length > size {
+			length = size - offset
+		}
+		query := url.Values{
+			"partNumber": {strconv.Itoa(number)},
+			"uploadId":   {uploadID},
+		}
+		req, err := h.newRequest(ctx, http.MethodPut, objectURL, query, io.NewSectionReader(r, offset, length))
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = length
+		header, err := h.do(req, fmt.Sprintf("upload part %d", number), nil)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, s3CompletedPart{PartNumber: number, ETag: header.Get("ETag")})
+	}
+	return parts, nil
+}
+
+// completeMultipartUpload assembles parts into the object of upload uploadID
+func (h *S3CarHost) completeMultipartUpload(ctx context.Context, objectURL *url.URL, uploadID string, parts []s3CompletedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+		Parts   []s3CompletedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	req, err := h.newRequest(ctx, http.MethodPost, objectURL, url.Values{"uploadId": {uploadID}}, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	// S3 may report a failed completion with a 200 and an Error document
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if _, err := h.do(req, "complete upload", &result); err != nil {
+		return err
+	}
+	if result.XMLName.Local == "Error" {
+		return fmt.Errorf("failed to complete upload to s3: %s: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+// abortMultipartUpload discards the parts of upload uploadID
+func (h *S3CarHost) abortMultipartUpload(ctx context.Context, objectURL *url.URL, uploadID string) error {
+	req, err := h.newRequest(ctx, http.MethodDelete, objectURL, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = h.do(req, "abort upload", nil)
+	return err
+}
+
+// newRequest returns a signed request to objectURL with the given query
+func (h *S3CarHost) newRequest(ctx context.Context, method string, objectURL *url.URL, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *objectURL
+	u.RawQuery = s3CanonicalQuery(query)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	h.signRequest(req, time.Now().UTC())
+	return req, nil
+}
+
+// do sends req, decodes the XML response body into result when it is not
+// nil, and returns the response header.
+func (h *S3CarHost) do(req *http.Request, action string, result interface{}) (http.Header, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s to s3: %w", action, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to %s to s3: unexpected status code %d: %s", action, resp.StatusCode, body)
+	}
+	if result != nil {
+		if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+			return nil, fmt.Errorf("failed to %s to s3: invalid response: %w", action, err)
+		}
+	}
+	return resp.Header, nil
+}
+
+func (h *S3CarHost) objectURL(name string) (*url.URL, error) {
+	u, err := url.Parse(h.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+	u.Path = "/" + path.Join(h.Bucket, h.Prefix, name)
+	return u, nil
+}
+
+func (h *S3CarHost) region() string {
+	if h.Region == "" {
+		return "us-east-1"
+	}
+	return h.Region
+}
+
+// signRequest adds the SigV4 Authorization header to req, leaving the
+// payload unsigned so that the body can be streamed. The query of req must be
+// canonical, as built by s3CanonicalQuery.
+func (h *S3CarHost) signRequest(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-date":           amzDate,
+		"x-amz-content-sha256": s3UnsignedPayload,
+	}
+	scope, signedHeaders, signature := h.sign(req.Method, req.URL.EscapedPath(), req.URL.RawQuery, headers, s3UnsignedPayload, now)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		h.AccessKey, scope, signedHeaders, signature))
+}
+
+// presignGet returns objectURL with a SigV4 query string signature allowing
+// anyone holding it to GET the object until it expires.
+func (h *S3CarHost) presignGet(objectURL *url.URL, now time.Time) string {
+	date := now.Format("20060102")
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s/%s/s3/aws4_request", h.AccessKey, date, h.region()))
+	query.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(h.URLExpiry/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+	canonicalQuery := s3CanonicalQuery(query)
+
+	headers := map[string]string{"host": objectURL.Host}
+	_, _, signature := h.sign(http.MethodGet, objectURL.EscapedPath(), canonicalQuery, headers, s3UnsignedPayload, now)
+
+	u := *objectURL
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+	return u.String()
+}
+
+// sign computes the SigV4 signature of a request given its canonical parts
+// and returns the credential scope, the signed header list and the signature.
+func (h *S3CarHost) sign(method string, escapedPath string, canonicalQuery string, headers map[string]string, payloadHash string, now time.Time) (string, string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		canonicalQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	date := now.Format("20060102")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, h.region())
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+h.SecretKey), date)
+	key = hmacSHA256(key, h.region())
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// s3CanonicalQuery encodes query sorted by key, escaping as SigV4 requires
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
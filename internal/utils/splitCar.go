@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// Rough per-item CAR overheads used when planning slices. Every block is
+// framed by a varint length and its CID and is linked from a parent node,
+// and every file is also linked by name from its directory. The numbers are
+// deliberately generous so that a planned CAR never outgrows its piece.
+const (
+	splitChunkOverhead = 128
+	splitFileOverhead  = 512
+)
+
+// GenerateSplitCarUtil splits the input into as many CARs as needed for each
+// of them to fit in a piece of c.SplitSize bytes, cutting files into
+// Start/End slices across CAR boundaries, and returns one Result per CAR.
+func (c *CarParams) GenerateSplitCarUtil() ([]Result, error) {
+	if c.SplitSize == 0 || c.SplitSize&(c.SplitSize-1) != 0 {
+		return nil, fmt.Errorf("split size must be a power of 2")
+	}
+	if c.PieceSize > 0 && c.PieceSize < c.SplitSize {
+		return nil, fmt.Errorf("piece size %d is smaller than split size %d", c.PieceSize, c.SplitSize)
+	}
+	if err := c.setDagParams(); err != nil {
+		return nil, err
+	}
+
+	input, err := c.inputFiles()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := PlanSlices(input, c.Parent, c.SplitSize, minChunkSize(c.Dag))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	results := make([]Result, 0, len(plan))
+	for i, files := range plan {
+		result, err := c.generateCar(ctx, files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate CAR %d of %d: %w", i+1, len(plan), err)
+		}
+		if result.PieceSize > c.SplitSize && c.PieceSize == 0 {
+			return nil, fmt.Errorf("CAR %d of %d has piece size %d, exceeding split size %d", i+1, len(plan), result.PieceSize, c.SplitSize)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// PlanSlices groups fileList into consecutive batches whose CARs fit in a
+// piece of pieceSize bytes when chunked into blocks of at least chunkSize
+// bytes. Files that do not fit in the space left in a batch are cut into
+// byte-range slices that continue in the next batch.
+func PlanSlices(fileList []Finfo, parentPath string, pieceSize uint64, chunkSize int64) ([][]Finfo, error) {
+	// a piece holds 127 payload bytes for every 128 bytes after fr32 padding
+	budget := int64(pieceSize / 128 * 127)
+	if budget <= splitFileOverhead+splitChunkOverhead {
+		return nil, fmt.Errorf("split size %d is too small", pieceSize)
+	}
+
+	var plan [][]Finfo
+	var batch []Finfo
+	var used int64
+	flush := func() {
+		if len(batch) > 0 {
+			plan = append(plan, batch)
+		}
+		batch = nil
+		used = 0
+	}
+
+	for _, item := range fileList {
+		if item.End == 0 {
+			item.End = item.Size
+		}
+		rel, err := filepath.Rel(filepath.Clean(parentPath), filepath.Clean(item.Path))
+		if err != nil {
+			return nil, err
+		}
+		fileOverhead := int64(splitFileOverhead + 2*len(rel))
+
+		start := item.Start
+		for {
+			free := budget - used - fileOverhead
+			if free <= 2*splitChunkOverhead {
+				if len(batch) == 0 {
+					return nil, fmt.Errorf("split size %d is too small for %s", pieceSize, item.Path)
+				}
+				flush()
+				continue
+			}
+			left := item.End - start
+			if size := left + sliceOverhead(left, chunkSize); size <= free {
+				batch = append(batch, Finfo{Path: item.Path, Size: item.Size, Start: start, End: item.End})
+				used += size + fileOverhead
+				break
+			}
+			take := (free - splitChunkOverhead) * chunkSize / (chunkSize + splitChunkOverhead)
+			if take >= left {
+				if len(batch) > 0 {
+					flush()
+					continue
+				}
+				take = left / 2
+			}
+			batch = append(batch, Finfo{Path: item.Path, Size: item.Size, Start: start, End: start + take})
+			start += take
+			flush()
+		}
+	}
+	flush()
+	return plan, nil
+}
+
+// sliceOverhead estimates the CAR bytes a slice of size bytes cut into
+// chunks of chunkSize bytes needs on top of its data for block framing and
+// links.
+func sliceOverhead(size int64, chunkSize int64) int64 {
+	chunks := (size + chunkSize - 1) / chunkSize
+	if chunks == 0 {
+		chunks = 1
+	}
+	return chunks * splitChunkOverhead
+}
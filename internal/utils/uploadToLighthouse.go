@@ -2,7 +2,9 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -10,9 +12,19 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/ipfs/boxo/blockservice"
+	bstore "github.com/ipfs/boxo/blockstore"
+	"github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
 )
 
-const lighthouseNodeURL = "https://node.lighthouse.storage"
+const (
+	lighthouseNodeURL    = "https://node.lighthouse.storage"
+	lighthouseGatewayURL = "https://gateway.lighthouse.storage/ipfs/"
+)
 
 type UploadFileResponse struct {
 	Name string `json:"Name"`
@@ -21,52 +33,173 @@ type UploadFileResponse struct {
 }
 
 func UploadToLighthouse(sourcePath, apiKey string) (*UploadFileResponse, error) {
-	endpoint := lighthouseNodeURL + "/api/v0/add?wrap-with-directory=false"
+	u := &LighthouseUploader{APIKey: apiKey}
+	return u.Upload(context.Background(), sourcePath)
+}
 
-	file, err := os.Open(sourcePath)
+// LighthouseUploader streams files to the Lighthouse add endpoint. Failed
+// uploads are retried with exponential backoff.
+//
+// The add endpoint takes a file in a single request and has no way to resume
+// one, so uploads are not chunked and every retry sends the whole file again.
+// What can be saved is an upload that went through but whose response was
+// lost: before retrying a failed attempt the gateway is asked for the hash
+// the file will get and the upload is skipped when it is already there.
+type LighthouseUploader struct {
+	APIKey string
+	// NodeURL and GatewayURL default to the public Lighthouse endpoints and
+	// can point at a stand-in server
+	NodeURL    string
+	GatewayURL string
+	Client     *http.Client
+	// MaxAttempts defaults to 5 and Backoff, doubled after every failed
+	// attempt, to 5s
+	MaxAttempts int
+	Backoff     time.Duration
+	// Progress, when set, is called with the bytes of the file sent so far
+	Progress func(sent int64, total int64)
+}
+
+// errPermanent wraps upload errors that retrying cannot fix
+type errPermanent struct{ error }
+
+func (e errPermanent) Unwrap() error { return e.error }
+
+// Upload sends the file at sourcePath and checks that the returned hash is
+// the UnixFS CID the file gets with the defaults of ipfs add, which Lighthouse
+// uses.
+func (u *LighthouseUploader) Upload(ctx context.Context, sourcePath string) (*UploadFileResponse, error) {
+	expected, err := FileCid(ctx, sourcePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to compute file cid: %w", err)
 	}
-	defer file.Close()
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+	maxAttempts := u.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 5
+	}
+	backoff := u.Backoff
+	if backoff == 0 {
+		backoff = 5 * time.Second
+	}
 
-	part, err := writer.CreateFormFile("file", filepath.Base(sourcePath))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && u.onGateway(ctx, expected) {
+			stat, err := os.Stat(sourcePath)
+			if err != nil {
+				return nil, err
+			}
+			return &UploadFileResponse{
+				Name: filepath.Base(sourcePath),
+				Hash: expected.String(),
+				Size: fmt.Sprint(stat.Size()),
+			}, nil
+		}
+
+		resp, err := u.upload(ctx, sourcePath)
+		if err == nil {
+			if resp.Hash != expected.String() {
+				return nil, fmt.Errorf("lighthouse returned hash %s, expected %s: the file may have been chunked with other settings than the ipfs add defaults", resp.Hash, expected)
+			}
+			return resp, nil
+		}
+		if errors.As(err, &errPermanent{}) || attempt == maxAttempts {
+			return nil, err
+		}
+		logger.Warnw("upload to lighthouse failed, retrying", "attempt", attempt, "in", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
 	}
+}
 
-	_, err = io.Copy(part, file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
+func (u *LighthouseUploader) client() *http.Client {
+	if u.Client != nil {
+		return u.Client
 	}
+	return &http.Client{Timeout: 2 * time.Hour}
+}
+
+// gatewayCheckTimeout bounds the request asking the gateway for a file
+const gatewayCheckTimeout = 10 * time.Second
 
-	err = writer.Close()
+// onGateway reports whether the gateway already serves c
+func (u *LighthouseUploader) onGateway(ctx context.Context, c cid.Cid) bool {
+	gatewayURL := u.GatewayURL
+	if gatewayURL == "" {
+		gatewayURL = lighthouseGatewayURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, gatewayURL+c.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+		return false
+	}
+	client := &http.Client{Timeout: gatewayCheckTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// upload makes a single attempt at sending the file, streaming it between
+// the multipart header and trailer so that it is never held in memory.
+func (u *LighthouseUploader) upload(ctx context.Context, sourcePath string) (*UploadFileResponse, error) {
+	nodeURL := u.NodeURL
+	if nodeURL == "" {
+		nodeURL = lighthouseNodeURL
 	}
+	endpoint := nodeURL + "/api/v0/add?wrap-with-directory=false"
 
-	req, err := http.NewRequest("POST", endpoint, body)
+	file, err := os.Open(sourcePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, errPermanent{fmt.Errorf("failed to open file: %w", err)}
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, errPermanent{err}
 	}
 
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+	head := &bytes.Buffer{}
+	writer := multipart.NewWriter(head)
+	if _, err := writer.CreateFormFile("file", filepath.Base(sourcePath)); err != nil {
+		return nil, errPermanent{fmt.Errorf("failed to create form file: %w", err)}
+	}
+	headLen := head.Len()
+	if err := writer.Close(); err != nil {
+		return nil, errPermanent{fmt.Errorf("failed to close multipart writer: %w", err)}
+	}
+	tail := head.Bytes()[headLen:]
+	body := io.MultiReader(
+		bytes.NewReader(head.Bytes()[:headLen]),
+		&progressReader{r: file, total: stat.Size(), progress: u.Progress},
+		bytes.NewReader(tail),
+	)
 
-	client := &http.Client{
-		Timeout: 2 * time.Hour,
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, errPermanent{fmt.Errorf("failed to create request: %w", err)}
 	}
+	req.ContentLength = int64(headLen) + stat.Size() + int64(len(tail))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+u.APIKey)
 
-	resp, err := client.Do(req)
+	resp, err := u.client().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		err := fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return nil, errPermanent{err}
+		}
+		return nil, err
 	}
 
 	var response UploadFileResponse
@@ -77,3 +210,72 @@ func UploadToLighthouse(sourcePath, apiKey string) (*UploadFileResponse, error)
 
 	return &response, nil
 }
+
+// progressInterval is how many bytes are read between progress reports
+const progressInterval = 16 << 20
+
+// progressReader reports the bytes read from r to progress every
+// progressInterval bytes and once all total bytes are read
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	reported int64
+	total    int64
+	progress func(int64, int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.progress != nil && n > 0 && (p.read-p.reported >= progressInterval || p.read == p.total) {
+		p.reported = p.read
+		p.progress(p.read, p.total)
+	}
+	return n, err
+}
+
+// PrintProgress is a LighthouseUploader.Progress that keeps an upload
+// progress line updated on stderr
+func PrintProgress(sent int64, total int64) {
+	fmt.Fprintf(os.Stderr, "\ruploaded %d/%d MiB (%d%%)", sent>>20, total>>20, sent*100/max(total, 1))
+	if sent == total {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// FileCid returns the CID ipfs add gives the file at filePath with its
+// default settings, which is the hash Lighthouse returns for it. Only the
+// root is needed, so the blocks are hashed and dropped rather than stored.
+func FileCid(ctx context.Context, filePath string) (cid.Cid, error) {
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		return cid.Undef, err
+	}
+	bs := bstore.NewBlockstore(datastore.NewNullDatastore())
+	dagServ := merkledag.NewDAGService(blockservice.New(bs, offline.Exchange(bs)))
+	cidBuilder, err := merkledag.PrefixForCidVersion(KuboDagParams.CidVersion)
+	if err != nil {
+		return cid.Undef, err
+	}
+	item := Finfo{Path: filePath, Size: stat.Size(), End: stat.Size()}
+	nd, err := BuildFileNode(ctx, item, dagServ, cidBuilder, KuboDagParams)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return nd.Cid(), nil
+}
+
+// LighthouseCarHost uploads CARs to Lighthouse and serves them through its
+// IPFS gateway
+type LighthouseCarHost struct {
+	APIKey string
+}
+
+func (h *LighthouseCarHost) Host(ctx context.Context, carPath string, providers []string) (*HostedCar, error) {
+	u := &LighthouseUploader{APIKey: h.APIKey, Progress: PrintProgress}
+	uploadResp, err := u.Upload(ctx, carPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Lighthouse: %w", err)
+	}
+	return &HostedCar{URL: lighthouseGatewayURL + uploadResp.Hash}, nil
+}
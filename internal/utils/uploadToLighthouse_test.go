@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLighthouse is a stand-in for the Lighthouse add endpoint and gateway.
+// It answers the first failures add requests with status, then returns hash.
+type fakeLighthouse struct {
+	t        *testing.T
+	hash     string
+	failures int
+	status   int
+	// onGateway is whether the gateway serves hash
+	onGateway bool
+
+	mu       sync.Mutex
+	adds     int
+	heads    int
+	uploaded []byte
+}
+
+func (l *fakeLighthouse) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodHead && strings.HasPrefix(r.URL.Path, "/ipfs/"):
+		l.heads++
+		if l.onGateway && r.URL.Path == "/ipfs/"+l.hash {
+			return
+		}
+		http.NotFound(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v0/add":
+		l.adds++
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			l.t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			l.t.Errorf("add: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if l.adds <= l.failures {
+			http.Error(w, "failed", l.status)
+			return
+		}
+		l.uploaded, _ = io.ReadAll(file)
+		json.NewEncoder(w).Encode(UploadFileResponse{Name: header.Filename, Hash: l.hash, Size: "1"})
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func newTestUploader(url string) *LighthouseUploader {
+	return &LighthouseUploader{
+		APIKey:      "test-key",
+		NodeURL:     url,
+		GatewayURL:  url + "/ipfs/",
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	}
+}
+
+func TestFileCid(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(filePath, []byte("hello world\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := FileCid(context.Background(), filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// echo "hello world" | ipfs add
+	if c.String() != "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o" {
+		t.Fatalf("got cid %s", c)
+	}
+}
+
+func TestLighthouseUploaderRetries(t *testing.T) {
+	carPath, data := writeTestCar(t, 3<<20)
+	expected, err := FileCid(context.Background(), carPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &fakeLighthouse{t: t, hash: expected.String(), failures: 2, status: http.StatusBadGateway}
+	srv := httptest.NewServer(l)
+	defer srv.Close()
+
+	u := newTestUploader(srv.URL)
+	var sent, total int64
+	u.Progress = func(s int64, n int64) { sent, total = s, n }
+	resp, err := u.Upload(context.Background(), carPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Hash != expected.String() || resp.Name != "test.car" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if l.adds != 3 {
+		t.Fatalf("got %d add requests, expected 3", l.adds)
+	}
+	if l.heads != 2 {
+		t.Fatalf("got %d gateway requests, expected one before each retry", l.heads)
+	}
+	if !bytes.Equal(l.uploaded, data) {
+		t.Fatal("uploaded file does not match the car")
+	}
+	if sent != int64(len(data)) || total != int64(len(data)) {
+		t.Fatalf("last progress %d/%d, expected %d", sent, total, len(data))
+	}
+}
+
+func TestLighthouseUploaderGivesUp(t *testing.T) {
+	carPath, _ := writeTestCar(t, 1024)
+	l := &fakeLighthouse{t: t, failures: 10, status: http.StatusInternalServerError}
+	srv := httptest.NewServer(l)
+	defer srv.Close()
+
+	if _, err := newTestUploader(srv.URL).Upload(context.Background(), carPath); err == nil {
+		t.Fatal("expected the upload to fail")
+	}
+	if l.adds != 3 {
+		t.Fatalf("got %d add requests, expected 3", l.adds)
+	}
+}
+
+func TestLighthouseUploaderPermanentError(t *testing.T) {
+	carPath, _ := writeTestCar(t, 1024)
+	l := &fakeLighthouse{t: t, failures: 10, status: http.StatusUnauthorized}
+	srv := httptest.NewServer(l)
+	defer srv.Close()
+
+	if _, err := newTestUploader(srv.URL).Upload(context.Background(), carPath); err == nil {
+		t.Fatal("expected the upload to fail")
+	}
+	if l.adds != 1 {
+		t.Fatalf("got %d add requests, a permanent error should not be retried", l.adds)
+	}
+}
+
+func TestLighthouseUploaderHashMismatch(t *testing.T) {
+	carPath, _ := writeTestCar(t, 1024)
+	l := &fakeLighthouse{t: t, hash: "QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o"}
+	srv := httptest.NewServer(l)
+	defer srv.Close()
+
+	_, err := newTestUploader(srv.URL).Upload(context.Background(), carPath)
+	if err == nil || !strings.Contains(err.Error(), "lighthouse returned hash") {
+		t.Fatalf("expected a hash mismatch, got %v", err)
+	}
+}
+
+// TestLighthouseUploaderSkipsRetryOnGateway fails the first upload after the
+// file reached the gateway, as when the response of an upload is lost
+func TestLighthouseUploaderSkipsRetryOnGateway(t *testing.T) {
+	carPath, _ := writeTestCar(t, 1024)
+	expected, err := FileCid(context.Background(), carPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &fakeLighthouse{t: t, hash: expected.String(), failures: 1, status: http.StatusGatewayTimeout, onGateway: true}
+	srv := httptest.NewServer(l)
+	defer srv.Close()
+
+	resp, err := newTestUploader(srv.URL).Upload(context.Background(), carPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.adds != 1 || l.heads != 1 {
+		t.Fatalf("got %d add and %d gateway requests, expected the retry to be skipped", l.adds, l.heads)
+	}
+	if resp.Hash != expected.String() || resp.Size != "1024" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/ipld/go-car"
+	carbs "github.com/ipld/go-car/v2/blockstore"
+	dagpb "github.com/ipld/go-codec-dagpb"
+	ipldprime "github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+)
+
+// CarVerification holds the values re-derived from a CAR by VerifyCar
+type CarVerification struct {
+	PayloadCid string `json:"payloadCid"`
+	PieceCid   string `json:"pieceCid"`
+	PieceSize  uint64 `json:"pieceSize"`
+	// NaturalPieceSize is the smallest piece the CAR fits in, before any
+	// padding up to the requested piece size
+	NaturalPieceSize uint64 `json:"naturalPieceSize"`
+	CarSize          uint64 `json:"carSize"`
+	BlockCount       uint64 `json:"blockCount"`
+	// DagBlocks is the number of blocks reached walking the DAG from the root
+	DagBlocks uint64 `json:"dagBlocks"`
+}
+
+// VerifyCar re-hashes every block of the CAR at carPath against its CID,
+// checks that the DAG under the header root is complete under allSelector,
+// and recomputes the commP, padded up to pieceSize when it is non-zero and
+// large enough to hold the CAR; Check reports a pieceSize that is too small.
+func VerifyCar(ctx context.Context, carPath string, pieceSize uint64) (*CarVerification, error) {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	cp := new(commp.Calc)
+	tee := io.TeeReader(f, cp)
+	cr, err := car.NewCarReader(tee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read car header: %w", err)
+	}
+	if len(cr.Header.Roots) != 1 {
+		return nil, fmt.Errorf("expected exactly one root in car header, found %d", len(cr.Header.Roots))
+	}
+
+	v := &CarVerification{
+		PayloadCid: cr.Header.Roots[0].String(),
+		CarSize:    uint64(stat.Size()),
+	}
+	for {
+		blk, err := cr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block %d: %w", v.BlockCount, err)
+		}
+		chk, err := blk.Cid().Prefix().Sum(blk.RawData())
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash block %s: %w", blk.Cid(), err)
+		}
+		if !chk.Equals(blk.Cid()) {
+			return nil, fmt.Errorf("block %d does not match its cid %s (hashes to %s)", v.BlockCount, blk.Cid(), chk)
+		}
+		v.BlockCount++
+	}
+	// make sure every byte of the file went through the commP hasher
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return nil, err
+	}
+
+	rawCommP, naturalSize, err := cp.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute commp: %w", err)
+	}
+	v.NaturalPieceSize = naturalSize
+	if pieceSize < naturalSize {
+		pieceSize = 0
+	}
+	commCid, paddedSize, err := padPieceCommitment(rawCommP, naturalSize, pieceSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pad commp: %w", err)
+	}
+	v.PieceCid = commCid.String()
+	v.PieceSize = paddedSize
+
+	v.DagBlocks, err = walkCarDag(ctx, carPath)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// walkCarDag traverses the DAG under the root of the CAR at carPath with
+// allSelector, failing on the first block that is missing or corrupt, and
+// returns the number of blocks visited.
+func walkCarDag(ctx context.Context, carPath string) (uint64, error) {
+	bs, err := carbs.OpenReadOnly(carPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open car blockstore: %w", err)
+	}
+	defer bs.Close()
+
+	roots, err := bs.Roots()
+	if err != nil {
+		return 0, err
+	}
+
+	var visited uint64
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageReadOpener = func(lctx ipldprime.LinkContext, lnk ipldprime.Link) (io.Reader, error) {
+		cl, ok := lnk.(cidlink.Link)
+		if !ok {
+			return nil, fmt.Errorf("unexpected link type %T", lnk)
+		}
+		blk, err := bs.Get(lctx.Ctx, cl.Cid)
+		if err != nil {
+			return nil, fmt.Errorf("dag is incomplete: block %s: %w", cl.Cid, err)
+		}
+		visited++
+		return bytes.NewReader(blk.RawData()), nil
+	}
+
+	sel, err := selector.ParseSelector(allSelector())
+	if err != nil {
+		return 0, err
+	}
+	chooser := dagpb.AddSupportToChooser(basicnode.Chooser)
+	rootLnk := cidlink.Link{Cid: roots[0]}
+	proto, err := chooser(rootLnk, ipldprime.LinkContext{Ctx: ctx})
+	if err != nil {
+		return 0, err
+	}
+	root, err := lsys.Load(ipldprime.LinkContext{Ctx: ctx}, rootLnk, proto)
+	if err != nil {
+		return 0, err
+	}
+	prog := traversal.Progress{
+		Cfg: &traversal.Config{
+			Ctx:                            ctx,
+			LinkSystem:                     lsys,
+			LinkTargetNodePrototypeChooser: chooser,
+		},
+	}
+	err = prog.WalkAdv(root, sel, func(traversal.Progress, ipldprime.Node, traversal.VisitReason) error { return nil })
+	if err != nil {
+		return 0, fmt.Errorf("failed to traverse dag: %w", err)
+	}
+	return visited, nil
+}
+
+// Check compares v against the expected values, skipping the ones that are
+// empty, and returns an error listing every mismatch.
+func (v *CarVerification) Check(payloadCid string, pieceCid string, pieceSize uint64, carSize uint64) error {
+	var mismatches []string
+	if payloadCid != "" && payloadCid != v.PayloadCid {
+		mismatches = append(mismatches, fmt.Sprintf("payload cid: expected %s, got %s", payloadCid, v.PayloadCid))
+	}
+	if pieceCid != "" && pieceCid != v.PieceCid {
+		mismatches = append(mismatches, fmt.Sprintf("commp: expected %s, got %s", pieceCid, v.PieceCid))
+	}
+	if pieceSize != 0 && pieceSize < v.NaturalPieceSize {
+		mismatches = append(mismatches, fmt.Sprintf("piece size: expected %d, but the car needs a piece of %d", pieceSize, v.NaturalPieceSize))
+	}
+	if carSize != 0 && carSize != v.CarSize {
+		mismatches = append(mismatches, fmt.Sprintf("car size: expected %d, got %d", carSize, v.CarSize))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("car verification failed:\n  %s", strings.Join(mismatches, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WebdavCarHost PUTs CARs under URL, on a WebDAV server or any endpoint that
+// accepts uploads with PUT, authenticating with basic auth when User is set.
+// Storage providers fetch them from under PublicURL, or URL when it is empty,
+// without credentials.
+type WebdavCarHost struct {
+	URL       string
+	PublicURL string
+	User      string
+	Password  string
+}
+
+func (h *WebdavCarHost) Host(ctx context.Context, carPath string, providers []string) (*HostedCar, error) {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(carPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(h.URL, "/")+"/"+name, f)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = stat.Size()
+	req.Header.Set("Content-Type", "application/vnd.ipld.car")
+	if h.User != "" {
+		req.SetBasicAuth(h.User, h.Password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload car: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, body)
+	}
+
+	publicURL := h.PublicURL
+	if publicURL == "" {
+		publicURL = h.URL
+	}
+	return &HostedCar{URL: strings.TrimSuffix(publicURL, "/") + "/" + name}, nil
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"log"
 	"os"
 
 	"github.com/eastore-project/fil-deal-wrapper/cmd"
@@ -11,17 +10,25 @@ import (
 
 func main() {
 	app := &cli.App{
-		Name:  "wrappedeal",
-		Usage: "A CLI tool for handling Smart Contract Filecoin deals",
+		Name:   "wrappedeal",
+		Usage:  "A CLI tool for handling Smart Contract Filecoin deals",
+		Flags:  []cli.Flag{cmd.OutputFlag},
+		Before: cmd.SetupOutput,
 		Commands: []*cli.Command{
 			cmd.FilCmd,
 			cmd.WriteContractCmd,
 			cmd.ReadContractCmd,
+			cmd.CarCmd,
+			cmd.ServeCmd,
+			cmd.DealsCmd,
+			cmd.TxCmd,
+			cmd.EthCmd,
 		},
 	}
 
 	err := app.Run(os.Args)
 	if err != nil {
-		log.Fatal(err)
+		cmd.PrintError(err)
+		os.Exit(1)
 	}
 }